@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/client"
+)
+
+// userAgent 便于服务端访问日志区分CLI请求
+const userAgent = "mcpforge-cli"
+
+// newClient 创建指向 --server 的客户端，authed 为 true 时带上已保存的token
+func newClient(g *globals, authed bool) (*client.Client, *credential, error) {
+	opts := []client.Option{client.WithUserAgent(userAgent)}
+	var cred *credential
+	if authed {
+		var err error
+		if cred, err = credentialFor(g); err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, client.WithToken(cred.Token))
+	}
+	c, err := client.New(g.server, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, cred, nil
+}
+
+// runLogin 用钱包私钥签名挑战并保存返回的token
+func runLogin(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("login", "login [--private-key-file path|-] [--username name] [--email addr] [--restore]")
+	keyFile := fs.String("private-key-file", "", "file holding the hex private key, - for stdin (default env MCPFORGE_PRIVATE_KEY)")
+	username := fs.String("username", "", "username when registering a new account")
+	email := fs.String("email", "", "email when registering a new account")
+	restore := fs.Bool("restore", false, "restore an account in its deletion grace period")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	key, err := readPrivateKey(*keyFile)
+	if err != nil {
+		return err
+	}
+	signer, err := client.NewPrivateKeySigner(key)
+	if err != nil {
+		return err
+	}
+
+	req := &client.Web3AuthRequest{Restore: *restore}
+	if *username != "" {
+		req.Username = username
+	}
+	if *email != "" {
+		req.Email = email
+	}
+
+	c, _, err := newClient(g, false)
+	if err != nil {
+		return err
+	}
+	resp, err := c.Login(ctx, signer, req)
+	if err != nil {
+		return err
+	}
+
+	cred := credential{
+		Token:     c.Token(),
+		Address:   signer.Address(),
+		UserID:    resp.User.UserID,
+		Username:  resp.User.Username,
+		ExpiresAt: tokenExpiry(c.Token()),
+	}
+	store, err := loadCredentials(g.credentials)
+	if err != nil {
+		return err
+	}
+	store.Servers[g.server] = cred
+	if err := store.save(g.credentials); err != nil {
+		return fmt.Errorf("save credentials: %w", err)
+	}
+
+	if g.json {
+		return printJSON(resp)
+	}
+	fmt.Printf("%s as %s (user %d, %s) on %s\n", loginVerb(resp.Action), cred.Username, cred.UserID, cred.Address, g.server)
+	return nil
+}
+
+// readPrivateKey 依次从文件、标准输入或环境变量读取私钥，不支持命令行参数以免出现在进程列表中
+func readPrivateKey(path string) (string, error) {
+	var data []byte
+	var err error
+	switch path {
+	case "":
+		key := os.Getenv("MCPFORGE_PRIVATE_KEY")
+		if key == "" {
+			return "", errors.New("no private key: use --private-key-file or set MCPFORGE_PRIVATE_KEY")
+		}
+		return strings.TrimSpace(key), nil
+	case "-":
+		data, err = io.ReadAll(os.Stdin)
+	default:
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read private key: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func loginVerb(action string) string {
+	switch action {
+	case "register":
+		return "Registered"
+	case "restore":
+		return "Restored account and logged in"
+	default:
+		return "Logged in"
+	}
+}
+
+// runLogout 通知服务端登出并删除本地凭据，服务端失败时仍删除本地凭据
+func runLogout(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("logout", "logout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := loadCredentials(g.credentials)
+	if err != nil {
+		return err
+	}
+	cred, ok := store.Servers[g.server]
+	if !ok {
+		fmt.Printf("Not logged in to %s\n", g.server)
+		return nil
+	}
+
+	var logoutErr error
+	if c, err := client.New(g.server, client.WithUserAgent(userAgent), client.WithToken(cred.Token)); err == nil {
+		logoutErr = c.Logout(ctx)
+	}
+	delete(store.Servers, g.server)
+	if err := store.save(g.credentials); err != nil {
+		return fmt.Errorf("save credentials: %w", err)
+	}
+	if logoutErr != nil && client.StatusCode(logoutErr) != 401 {
+		return fmt.Errorf("removed local credentials, but server logout failed: %w", logoutErr)
+	}
+	fmt.Printf("Logged out of %s\n", g.server)
+	return nil
+}
+
+// runWhoami 从服务端读取当前账户，确认token仍然有效
+func runWhoami(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("whoami", "whoami")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, cred, err := newClient(g, true)
+	if err != nil {
+		return err
+	}
+	user, err := c.GetUser(ctx, cred.UserID)
+	if err != nil {
+		return err
+	}
+
+	if g.json {
+		return printJSON(user)
+	}
+	fmt.Printf("user:     %s (%d)\n", user.Username, user.UserID)
+	fmt.Printf("role:     %s\n", user.Role)
+	fmt.Printf("address:  %s\n", cred.Address)
+	fmt.Printf("server:   %s\n", g.server)
+	if !cred.ExpiresAt.IsZero() {
+		fmt.Printf("expires:  %s\n", cred.ExpiresAt.Local().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// runToken 输出已保存的token，供 curl 等工具使用
+func runToken(_ context.Context, g *globals, args []string) error {
+	fs := newFlagSet("token", "token")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cred, err := credentialFor(g)
+	if err != nil {
+		return err
+	}
+	if g.json {
+		return printJSON(cred)
+	}
+	fmt.Println(cred.Token)
+	return nil
+}
+
+// runStatus 输出服务状态，不需要登录
+func runStatus(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("status", "status")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, _, err := newClient(g, false)
+	if err != nil {
+		return err
+	}
+	status, err := c.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if g.json {
+		return printJSON(status)
+	}
+	fmt.Printf("status:       %s\n", status.Status)
+	fmt.Printf("environment:  %s\n", status.Environment)
+	fmt.Printf("version:      %s\n", status.Build.Version)
+	fmt.Printf("uptime:       %s\n", time.Duration(status.UptimeSeconds)*time.Second)
+	return nil
+}
+
+// cliConfig config 命令输出的客户端配置
+type cliConfig struct {
+	Server      string `json:"server"`
+	Credentials string `json:"credentials"`
+	LoggedIn    bool   `json:"logged_in"`
+	Username    string `json:"username,omitempty"`
+	Address     string `json:"address,omitempty"`
+}
+
+// runConfig 输出CLI当前使用的服务地址和凭据位置
+func runConfig(_ context.Context, g *globals, args []string) error {
+	fs := newFlagSet("config", "config")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg := cliConfig{Server: g.server, Credentials: g.credentials}
+	if cred, err := credentialFor(g); err == nil {
+		cfg.LoggedIn = true
+		cfg.Username = cred.Username
+		cfg.Address = cred.Address
+	}
+
+	if g.json {
+		return printJSON(cfg)
+	}
+	fmt.Printf("server:       %s\n", cfg.Server)
+	fmt.Printf("credentials:  %s\n", cfg.Credentials)
+	if cfg.LoggedIn {
+		fmt.Printf("logged in:    %s (%s)\n", cfg.Username, cfg.Address)
+	} else {
+		fmt.Println("logged in:    no")
+	}
+	return nil
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
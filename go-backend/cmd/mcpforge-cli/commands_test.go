@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func TestReadPrivateKey(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "key")
+		if err := os.WriteFile(path, []byte(testKey+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if key, err := readPrivateKey(path); err != nil || key != testKey {
+			t.Errorf("readPrivateKey = %q, %v", key, err)
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "stdin")
+		if err := os.WriteFile(path, []byte("  "+testKey+"\r\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		stdin, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stdin.Close()
+		original := os.Stdin
+		os.Stdin = stdin
+		t.Cleanup(func() { os.Stdin = original })
+
+		if key, err := readPrivateKey("-"); err != nil || key != testKey {
+			t.Errorf("readPrivateKey = %q, %v", key, err)
+		}
+	})
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("MCPFORGE_PRIVATE_KEY", " "+testKey+"\n")
+		if key, err := readPrivateKey(""); err != nil || key != testKey {
+			t.Errorf("readPrivateKey = %q, %v", key, err)
+		}
+	})
+
+	// 指定文件时不回退到环境变量
+	t.Run("file takes precedence over env", func(t *testing.T) {
+		t.Setenv("MCPFORGE_PRIVATE_KEY", "from-env")
+		if _, err := readPrivateKey(filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("missing file accepted")
+		}
+	})
+
+	t.Run("no source", func(t *testing.T) {
+		t.Setenv("MCPFORGE_PRIVATE_KEY", "")
+		if _, err := readPrivateKey(""); err == nil {
+			t.Error("missing key accepted")
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// credential 某个服务上的登录凭据
+type credential struct {
+	Token     string    `json:"token"`
+	Address   string    `json:"address"`
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// credentialStore 凭据文件内容，按服务地址保存，可同时登录多个环境
+type credentialStore struct {
+	Servers map[string]credential `json:"servers"`
+}
+
+// defaultCredentialsPath 用户配置目录下的凭据文件
+func defaultCredentialsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ".mcpforge-credentials.json"
+	}
+	return filepath.Join(dir, "mcpforge", "credentials.json")
+}
+
+// loadCredentials 读取凭据文件，文件不存在时返回空的存储
+func loadCredentials(path string) (*credentialStore, error) {
+	store := &credentialStore{Servers: make(map[string]credential)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if store.Servers == nil {
+		store.Servers = make(map[string]credential)
+	}
+	return store, nil
+}
+
+// save 只允许当前用户读写，先写临时文件再替换，避免中断时损坏凭据
+func (s *credentialStore) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// credentialFor 返回服务上未过期的凭据
+func credentialFor(g *globals) (*credential, error) {
+	store, err := loadCredentials(g.credentials)
+	if err != nil {
+		return nil, err
+	}
+	cred, ok := store.Servers[g.server]
+	if !ok {
+		return nil, fmt.Errorf("not logged in to %s, run 'mcpforge-cli login'", g.server)
+	}
+	if !cred.ExpiresAt.IsZero() && time.Now().After(cred.ExpiresAt) {
+		return nil, fmt.Errorf("token for %s expired at %s, run 'mcpforge-cli login'", g.server, cred.ExpiresAt.Format(time.RFC3339))
+	}
+	return &cred, nil
+}
+
+// tokenExpiry 读取JWT的过期时间，签名由服务端校验，这里不验证
+func tokenExpiry(token string) time.Time {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil || claims.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return claims.ExpiresAt.Time
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestCredentialsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcpforge", "credentials.json")
+
+	store, err := loadCredentials(path)
+	if err != nil || len(store.Servers) != 0 {
+		t.Fatalf("missing file = %+v, %v", store, err)
+	}
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	store.Servers["https://api.example.com"] = credential{Token: "prod", Address: "0xa1", UserID: 1, Username: "alice", ExpiresAt: expires}
+	store.Servers["http://localhost:8443"] = credential{Token: "local", Address: "0xb2", UserID: 2, Username: "bob"}
+	if err := store.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("mode = %o, want 600", mode)
+	}
+	// 替换文件时不留下临时文件
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("%d files in the credentials directory", len(entries))
+	}
+
+	loaded, err := loadCredentials(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Servers) != 2 {
+		t.Fatalf("servers = %+v", loaded.Servers)
+	}
+	if got := loaded.Servers["https://api.example.com"]; got.Token != "prod" || got.Username != "alice" || !got.ExpiresAt.Equal(expires) {
+		t.Errorf("prod credential = %+v", got)
+	}
+	if got := loaded.Servers["http://localhost:8443"]; got.Token != "local" || !got.ExpiresAt.IsZero() {
+		t.Errorf("local credential = %+v", got)
+	}
+
+	// 覆盖已有文件时权限保持不变
+	delete(loaded.Servers, "http://localhost:8443")
+	if err := loaded.save(path); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("mode after rewrite = %o", info.Mode().Perm())
+	}
+	if again, _ := loadCredentials(path); len(again.Servers) != 1 {
+		t.Errorf("servers after rewrite = %+v", again.Servers)
+	}
+}
+
+func TestLoadCredentialsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCredentials(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestCredentialFor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	store := &credentialStore{Servers: map[string]credential{
+		"http://valid":   {Token: "valid", ExpiresAt: time.Now().Add(time.Hour)},
+		"http://expired": {Token: "expired", ExpiresAt: time.Now().Add(-time.Minute)},
+		"http://forever": {Token: "forever"},
+	}}
+	if err := store.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		server, token, err string
+	}{
+		{"http://valid", "valid", ""},
+		{"http://forever", "forever", ""},
+		{"http://expired", "", "expired"},
+		{"http://other", "", "not logged in"},
+	}
+	for _, tt := range tests {
+		cred, err := credentialFor(&globals{server: tt.server, credentials: path})
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: err = %v, want %q", tt.server, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || cred.Token != tt.token {
+			t.Errorf("%s: credential = %+v, %v", tt.server, cred, err)
+		}
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	expires := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(expires),
+	}).SignedString([]byte("any key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tokenExpiry(token); !got.Equal(expires) {
+		t.Errorf("tokenExpiry = %s, want %s", got, expires)
+	}
+
+	noExpiry, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "1"}).SignedString([]byte("any key"))
+	for _, token := range []string{noExpiry, "not-a-jwt", ""} {
+		if got := tokenExpiry(token); !got.IsZero() {
+			t.Errorf("tokenExpiry(%q) = %s, want zero", token, got)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// mcpforge-cli 是 MCPForge API 的命令行客户端，基于 pkg/client，
+// 支持用钱包私钥登录并在本地保存凭据，便于CI流水线调用接口。
+//
+// 服务器和卡片目前只能查看。设备码登录、部署和删除服务器、查看日志、
+// 导入卡片以及API密钥管理依赖的接口Go后端尚未提供，因此暂无对应命令
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// defaultServer 未指定服务地址时使用本地开发服务
+const defaultServer = "http://localhost:8443"
+
+// globals 所有子命令共用的参数
+type globals struct {
+	server      string
+	credentials string
+	json        bool
+}
+
+// command 子命令定义
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, g *globals, args []string) error
+}
+
+// commands 按帮助信息中的显示顺序排列
+var commands = []command{
+	{"login", "sign in with a wallet private key and store the token", runLogin},
+	{"logout", "sign out and remove the stored token", runLogout},
+	{"whoami", "show the signed-in account", runWhoami},
+	{"token", "print the stored token for use in other tools", runToken},
+	{"status", "show server status and build info", runStatus},
+	{"config", "print the client configuration", runConfig},
+	{"servers", "list MCP servers, or inspect one by name", runServers},
+	{"cards", "list marketplace cards, or inspect one by id", runCards},
+}
+
+func main() {
+	g := &globals{}
+	fs := flag.NewFlagSet("mcpforge-cli", flag.ContinueOnError)
+	fs.StringVar(&g.server, "server", envOr("MCPFORGE_SERVER", defaultServer), "API server URL (env MCPFORGE_SERVER)")
+	fs.StringVar(&g.credentials, "credentials", envOr("MCPFORGE_CREDENTIALS", defaultCredentialsPath()), "credentials file (env MCPFORGE_CREDENTIALS)")
+	fs.BoolVar(&g.json, "json", false, "print machine-readable JSON")
+	fs.Usage = printUsage
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(2)
+	}
+	g.server = strings.TrimSuffix(g.server, "/")
+
+	args := fs.Args()
+	if len(args) == 0 || args[0] == "help" {
+		printUsage()
+		return
+	}
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		printUsage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, g, args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "mcpforge-cli %s: %v\n", cmd.name, err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage() {
+	var b strings.Builder
+	b.WriteString("usage: mcpforge-cli [--server url] [--credentials file] [--json] <command> [args]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	b.WriteString("\nRun 'mcpforge-cli <command> --help' for command flags.\n")
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+// newFlagSet 创建子命令参数解析器，--help 时输出用法
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: mcpforge-cli %s\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// runServers 列出MCP服务器，指定名称时输出单个服务器，不需要登录
+func runServers(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("servers", "servers [name]")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, _, err := newClient(g, false)
+	if err != nil {
+		return err
+	}
+	if name := fs.Arg(0); name != "" {
+		server, err := c.GetMCPServer(ctx, name)
+		if err != nil {
+			return err
+		}
+		if g.json {
+			return printJSON(server)
+		}
+		fmt.Printf("name:     %s\n", server.Name)
+		fmt.Printf("image:    %s\n", server.Image)
+		fmt.Printf("created:  %s\n", server.CreatedAt.Local().Format(time.RFC3339))
+		if len(server.Status) > 0 {
+			fmt.Printf("status:   %s\n", server.Status)
+		}
+		return nil
+	}
+
+	servers, err := c.ListMCPServers(ctx)
+	if err != nil {
+		return err
+	}
+	if g.json {
+		return printJSON(servers)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tIMAGE\tCREATED")
+	for _, server := range servers {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", server.Name, server.Image, server.CreatedAt.Local().Format(time.RFC3339))
+	}
+	return w.Flush()
+}
+
+// runCards 列出市场卡片，指定ID时输出单张卡片，不需要登录
+func runCards(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("cards", "cards [id]")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, _, err := newClient(g, false)
+	if err != nil {
+		return err
+	}
+	if arg := fs.Arg(0); arg != "" {
+		id, err := strconv.ParseUint(arg, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid card id %q", arg)
+		}
+		card, err := c.GetMCPCard(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+		if g.json {
+			return printJSON(card)
+		}
+		fmt.Printf("id:       %d\n", card.ID)
+		fmt.Printf("name:     %s\n", deref(card.Name))
+		fmt.Printf("github:   %s\n", card.GithubURL)
+		if card.DockerImage != nil {
+			fmt.Printf("image:    %s\n", *card.DockerImage)
+		}
+		if card.Description != nil {
+			fmt.Printf("\n%s\n", *card.Description)
+		}
+		return nil
+	}
+
+	cards, err := c.ListMCPCards(ctx)
+	if err != nil {
+		return err
+	}
+	if g.json {
+		return printJSON(cards)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tGITHUB")
+	for _, card := range cards {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", card.ID, deref(card.Name), card.GithubURL)
+	}
+	return w.Flush()
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
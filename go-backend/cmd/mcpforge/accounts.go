@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+)
+
+// seedAccounts 本地开发账户，地址为 Hardhat/Anvil 默认助记词的前三个账户，
+// 便于直接用已知私钥完成钱包登录
+var seedAccounts = []struct {
+	username string
+	address  string
+	role     models.UserRole
+}{
+	{"dev-admin", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", models.UserRoleAdmin},
+	{"dev-developer", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", models.UserRoleDeveloper},
+	{"dev-user", "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC", models.UserRoleUser},
+}
+
+// runSeed 创建本地开发账户，重复执行是安全的
+func runSeed(ctx context.Context, cfg *config.Config, appLogger *logger.Logger, args []string) error {
+	fs := newFlagSet("seed", "seed [--force]")
+	force := fs.Bool("force", false, "allow seeding a production database")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if cfg.IsProduction() && !*force {
+		return errors.New("refusing to seed a production database without --force")
+	}
+
+	c, err := newContainer(ctx, cfg, appLogger)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	for _, account := range seedAccounts {
+		user, created, err := c.provisionAccount(ctx, "seed", account.username, account.address, account.role)
+		if err != nil {
+			return fmt.Errorf("seed %s: %w", account.username, err)
+		}
+		appLogger.Info("Seeded account", "user_id", user.UserID, "username", user.Username, "role", user.Role, "created", created)
+	}
+	return nil
+}
+
+// runCreateAdmin 创建管理员账户，钱包已注册时将其提升为管理员
+func runCreateAdmin(ctx context.Context, cfg *config.Config, appLogger *logger.Logger, args []string) error {
+	fs := newFlagSet("create-admin", "create-admin --address 0x... [--username name]")
+	address := fs.String("address", "", "wallet address of the admin (required)")
+	username := fs.String("username", "admin", "username used when a new account is created")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *address == "" {
+		fs.Usage()
+		return errors.New("--address is required")
+	}
+
+	c, err := newContainer(ctx, cfg, appLogger)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	user, created, err := c.provisionAccount(ctx, "create-admin", *username, *address, models.UserRoleAdmin)
+	if err != nil {
+		return err
+	}
+	if created {
+		appLogger.Info("Admin account created", "user_id", user.UserID, "username", user.Username)
+	} else {
+		appLogger.Info("Existing account is now an admin", "user_id", user.UserID, "username", user.Username)
+	}
+	return nil
+}
+
+// provisionAccount 按钱包地址创建或查找账户并设置角色，角色变更记录审计事件
+func (c *container) provisionAccount(ctx context.Context, command, username, address string, role models.UserRole) (*models.User, bool, error) {
+	user, created, err := c.userService.ProvisionWeb3User(ctx, username, address)
+	if err != nil {
+		return nil, false, err
+	}
+	application, err := c.roleApplicationService.Provision(user.UserID, role, operatorName(command))
+	if err != nil {
+		return nil, false, err
+	}
+	if application != nil {
+		c.logger.Info("Role provisioned", "user_id", user.UserID, "role", role, "role_application_id", application.ID)
+	}
+	user.Role = role
+	return user, created, nil
+}
+
+// operatorName 审计记录中的执行者：操作系统用户、主机和命令
+func operatorName(command string) string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		name += "@" + host
+	}
+	return name + " via mcpforge " + command
+}
+
+// runIssueToken 为已有用户签发token并输出到标准输出，用于运维调试和脚本
+func runIssueToken(ctx context.Context, cfg *config.Config, appLogger *logger.Logger, args []string) error {
+	fs := newFlagSet("issue-token", "issue-token --user-id N [--ttl 1h]")
+	userID := fs.Uint("user-id", 0, "id of the user the token is issued for (required)")
+	ttl := fs.Duration("ttl", time.Duration(cfg.Auth.JWTExpiresHours)*time.Hour, "token lifetime")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *userID == 0 {
+		fs.Usage()
+		return errors.New("--user-id is required")
+	}
+	if *ttl <= 0 {
+		return errors.New("--ttl must be positive")
+	}
+
+	c, err := newContainer(ctx, cfg, appLogger)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	user, err := c.userService.GetAuthUser(ctx, *userID)
+	if err != nil {
+		return err
+	}
+
+	token, err := utils.NewJWTUtil(cfg).GenerateTokenWithTTL(user.UserID, user.Username, string(user.Role), *ttl)
+	if err != nil {
+		return err
+	}
+
+	appLogger.Info("Token issued", "user_id", user.UserID, "role", user.Role, "ttl", ttl.String())
+	_, err = fmt.Fprintln(os.Stdout, token)
+	return err
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+const configUsage = "usage: config print"
+
+// runConfig 输出隐藏敏感字段后的最终配置
+func runConfig(_ context.Context, cfg *config.Config, _ *logger.Logger, args []string) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errors.New(configUsage)
+	}
+	return cfg.WriteYAML(os.Stdout)
+}
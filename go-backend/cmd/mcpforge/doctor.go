@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/database"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/health"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/migrations"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// errSkipped 依赖未配置，跳过检查
+var errSkipped = errors.New("not configured")
+
+// doctorCheck 一项诊断检查，返回成功时的说明
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context, cfg *config.Config) (string, error)
+}
+
+var doctorChecks = []doctorCheck{
+	{"config", checkConfig},
+	{"database", checkDatabase},
+	{"chain-rpc", checkChainRPC},
+	{"kubernetes", checkKubernetes},
+	{"mail", checkMail},
+}
+
+// runDoctor 依次检查配置和外部依赖，任一检查失败时返回错误
+func runDoctor(_ context.Context, cfg *config.Config, _ *logger.Logger, args []string) error {
+	fs := newFlagSet("doctor", "doctor [--timeout 5s]")
+	timeout := fs.Duration("timeout", 5*time.Second, "timeout for each check")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tSTATUS\tDETAIL")
+	failed := 0
+	for _, check := range doctorChecks {
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		detail, err := check.run(ctx, cfg)
+		cancel()
+
+		status := "ok"
+		switch {
+		case errors.Is(err, errSkipped):
+			status, detail = "skipped", err.Error()
+		case err != nil:
+			status, detail = "FAIL", err.Error()
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.name, status, detail)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkConfig 配置在加载时已校验，这里只报告运行环境
+func checkConfig(_ context.Context, cfg *config.Config) (string, error) {
+	return "environment " + cfg.Server.Env, nil
+}
+
+// checkDatabase 检查数据库连通性以及是否有未执行的迁移
+func checkDatabase(ctx context.Context, cfg *config.Config) (string, error) {
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		return "", err
+	}
+	defer database.Close(db)
+	sqlDB, err := db.DB()
+	if err != nil {
+		return "", err
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return "", err
+	}
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return "", err
+	}
+	pending, err := migrator.Pending()
+	if err != nil {
+		return "", err
+	}
+	if len(pending) > 0 {
+		return "", fmt.Errorf("%d pending migration(s), run 'mcpforge migrate up'", len(pending))
+	}
+	return "connected, schema up to date", nil
+}
+
+// checkChainRPC 调用 eth_chainId 并与配置的链ID比较
+func checkChainRPC(ctx context.Context, cfg *config.Config) (string, error) {
+	if cfg.Chain.RPCURL == "" {
+		return "", errSkipped
+	}
+
+	chainID, err := health.FetchChainID(ctx, cfg.Chain.RPCURL)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Chain.ChainID != 0 && chainID != int64(cfg.Chain.ChainID) {
+		return "", fmt.Errorf("node reports chain id %d, expected %d", chainID, cfg.Chain.ChainID)
+	}
+	return fmt.Sprintf("chain id %d", chainID), nil
+}
+
+// checkKubernetes 使用配置的token访问 /version
+func checkKubernetes(ctx context.Context, cfg *config.Config) (string, error) {
+	if cfg.Kubernetes.APIHost == "" {
+		return "", errSkipped
+	}
+
+	version, err := health.FetchKubernetesVersion(ctx, cfg.Kubernetes.APIHost, cfg.Kubernetes.BearerToken)
+	if err != nil {
+		return "", err
+	}
+	return "server " + version, nil
+}
+
+// checkMail 检查SMTP服务器是否可达
+func checkMail(ctx context.Context, cfg *config.Config) (string, error) {
+	if cfg.Mail.Host == "" {
+		return "", errSkipped
+	}
+
+	addr := net.JoinHostPort(cfg.Mail.Host, strconv.Itoa(cfg.Mail.Port))
+	if err := health.TCP(addr)(ctx); err != nil {
+		return "", err
+	}
+	return "reachable at " + addr, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// runRotateKeys 生成新的JWT签名密钥。当前密钥转为旧密钥继续用于验证，
+// 已签发的token在过期前仍然有效，部署后即可完成轮换
+func runRotateKeys(_ context.Context, cfg *config.Config, _ *logger.Logger, args []string) error {
+	fs := newFlagSet("rotate-keys", "rotate-keys [--bytes 48] [--keep 1]")
+	size := fs.Int("bytes", 48, "random bytes in the new secret")
+	keep := fs.Int("keep", 1, "number of retired secrets to keep accepting")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *size < 32 {
+		return errors.New("--bytes must be at least 32")
+	}
+	if *keep < 0 {
+		return errors.New("--keep must not be negative")
+	}
+
+	buf := make([]byte, *size)
+	if _, err := rand.Read(buf); err != nil {
+		return err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	previous, dropped := retiredSecrets(cfg.Auth.JWTSecret, cfg.Auth.JWTPreviousSecrets, *keep)
+
+	fmt.Fprintln(os.Stderr, "# Deploy the new secret; tokens signed with the retired secrets stay valid until they expire.")
+	if dropped > 0 {
+		fmt.Fprintf(os.Stderr, "# Dropped %d weak or development secret(s); tokens signed with them stop working after the deploy.\n", dropped)
+	}
+	fmt.Fprintf(os.Stdout, "JWT_SECRET=%s\n", secret)
+	fmt.Fprintf(os.Stdout, "JWT_PREVIOUS_SECRETS=%s\n", strings.Join(previous, ","))
+	return nil
+}
+
+// retiredSecrets 返回轮换后仍用于验证的旧密钥，最多keep个。开发默认值和过短的密钥
+// 任何人都可能知道，不再保留，否则部署后仍可用它们伪造token
+func retiredSecrets(current string, previous []string, keep int) (kept []string, dropped int) {
+	for _, secret := range append([]string{current}, previous...) {
+		if config.CheckJWTSecret(secret) != nil {
+			dropped++
+			continue
+		}
+		if len(kept) < keep {
+			kept = append(kept, secret)
+		}
+	}
+	return kept, dropped
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRetiredSecrets(t *testing.T) {
+	strong := func(c string) string { return strings.Repeat(c, 40) }
+
+	tests := []struct {
+		name     string
+		current  string
+		previous []string
+		keep     int
+		want     []string
+		dropped  int
+	}{
+		{"current becomes previous", strong("a"), []string{strong("b")}, 1, []string{strong("a")}, 0},
+		{"keep several", strong("a"), []string{strong("b"), strong("c")}, 2, []string{strong("a"), strong("b")}, 0},
+		{"keep none", strong("a"), nil, 0, nil, 0},
+		{"development default", "insecure-development-secret", nil, 1, nil, 1},
+		{"short current", "short", []string{strong("b")}, 1, []string{strong("b")}, 1},
+		{"weak previous", strong("a"), []string{"insecure-development-secret", "short", strong("c")}, 3, []string{strong("a"), strong("c")}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dropped := retiredSecrets(tt.current, tt.previous, tt.keep)
+			if !slices.Equal(got, tt.want) || dropped != tt.dropped {
+				t.Errorf("retiredSecrets = %q, %d; want %q, %d", got, dropped, tt.want, tt.dropped)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/database"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/legacyimport"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/migrations"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// legacySourceEnv 旧版NestJS数据库连接串的环境变量
+const legacySourceEnv = "LEGACY_DATABASE_URL"
+
+// runLegacyImport 将NestJS/TypeORM旧库的数据导入配置的数据库。
+// 默认只输出差异，加 --apply 才写入；写入后重新比较两端数据并输出校验报告。
+// 重复执行是幂等的，已导入且未变化的行不会被改写
+func runLegacyImport(ctx context.Context, cfg *config.Config, appLogger *logger.Logger, args []string) error {
+	fs := newFlagSet("legacy-import", "legacy-import --source postgres://... [--apply] [--details]")
+	source := fs.String("source", os.Getenv(legacySourceEnv), "legacy NestJS database DSN (env "+legacySourceEnv+")")
+	apply := fs.Bool("apply", false, "write the changes to the target database")
+	details := fs.Bool("details", false, "list the ids of every inserted, updated and extra row")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *source == "" {
+		return errors.New("--source or " + legacySourceEnv + " is required")
+	}
+
+	sourceDB, err := database.Open(config.DatabaseConfig{Driver: config.DatabaseDriverPostgres, URL: *source})
+	if err != nil {
+		return fmt.Errorf("connect to source: %w", err)
+	}
+	defer database.Close(sourceDB)
+
+	target, err := connectDatabase(ctx, cfg, appLogger)
+	if err != nil {
+		return err
+	}
+	defer database.Close(target)
+
+	// 目标库必须已执行全部迁移
+	migrator, err := migrations.New(target)
+	if err != nil {
+		return err
+	}
+	if err := migrator.EnsureUpToDate(); err != nil {
+		return err
+	}
+
+	importer := legacyimport.New(sourceDB, target)
+	plan, err := importer.Plan()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("== Import plan")
+	printImportPlan(os.Stdout, plan, *details)
+
+	if plan.HasConflicts() {
+		return legacyimport.ErrConflicts
+	}
+	if !*apply {
+		fmt.Println("\nDry run, no changes written. Re-run with --apply to import.")
+		return nil
+	}
+
+	if err := importer.Apply(plan); err != nil {
+		return err
+	}
+
+	report, err := importer.Plan()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\n== Verification")
+	printImportPlan(os.Stdout, report, true)
+
+	if !report.Verified() {
+		return errors.New("verification failed: target does not match source")
+	}
+	fmt.Println("\nVerification passed.")
+	return nil
+}
+
+func printImportPlan(out io.Writer, plan *legacyimport.Plan, details bool) {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tSOURCE\tTARGET\tINSERT\tUPDATE\tUNCHANGED\tEXTRA\tCONFLICTS")
+	for _, t := range plan.Tables {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n",
+			t.Table, t.Source, t.Target, len(t.Inserts), len(t.Updates), t.Unchanged, len(t.Extra), len(t.Conflicts))
+	}
+	w.Flush()
+
+	for _, t := range plan.Tables {
+		for _, conflict := range t.Conflicts {
+			fmt.Fprintf(out, "conflict %s: %s\n", t.Table, conflict)
+		}
+		if details {
+			printImportIDs(out, t.Table, "insert", t.Inserts)
+			printImportIDs(out, t.Table, "update", t.Updates)
+			printImportIDs(out, t.Table, "extra", t.Extra)
+		}
+	}
+
+	for _, note := range plan.Notes {
+		fmt.Fprintf(out, "note: %s\n", note)
+	}
+}
+
+func printImportIDs(out io.Writer, table, kind string, ids []uint) {
+	if len(ids) > 0 {
+		fmt.Fprintf(out, "%s %s: %v\n", kind, table, ids)
+	}
+}
@@ -0,0 +1,116 @@
+// mcpforge 是 MCPForge 后端的唯一入口，提供服务启动与运维子命令
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// command 子命令定义
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, cfg *config.Config, logger *logger.Logger, args []string) error
+}
+
+// commands 按帮助信息中的显示顺序排列
+var commands = []command{
+	{"serve", "start the HTTP server (default)", runServe},
+	{"migrate", "apply, revert or list database migrations", runMigrate},
+	{"legacy-import", "import data from the legacy NestJS database", runLegacyImport},
+	{"seed", "create local development accounts", runSeed},
+	{"create-admin", "create an admin account or promote an existing wallet", runCreateAdmin},
+	{"issue-token", "issue a JWT for an existing user", runIssueToken},
+	{"rotate-keys", "generate a new JWT signing secret", runRotateKeys},
+	{"doctor", "check configuration and connectivity to dependencies", runDoctor},
+	{"config", "print the effective configuration with secrets redacted", runConfig},
+}
+
+func main() {
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			printUsage()
+			return
+		}
+		fmt.Fprintln(os.Stderr, "Invalid configuration:")
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	appLogger := logger.New(cfg.Log.Level)
+	// 服务层通过 logger.FromContext 取不到请求日志记录器时使用默认记录器
+	slog.SetDefault(appLogger.Logger)
+
+	name := "serve"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" {
+		printUsage()
+		return
+	}
+
+	cmd, ok := findCommand(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage()
+		os.Exit(2)
+	}
+
+	// SIGINT/SIGTERM 取消ctx，由各子命令决定如何优雅退出
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := cmd.run(ctx, cfg, appLogger, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+			appLogger.Warn("Command interrupted", "command", cmd.name)
+			stop()
+			os.Exit(1)
+		}
+		appLogger.Error("Command failed", "command", cmd.name, "error", err.Error())
+		stop()
+		os.Exit(1)
+	}
+}
+
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage() {
+	var b strings.Builder
+	b.WriteString("usage: mcpforge [--config file] [config flags] <command> [args]\n\ncommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(&b, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	b.WriteString("\nRun 'mcpforge --help' for config flags and 'mcpforge <command> --help' for command flags.\n")
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+// newFlagSet 创建子命令参数解析器，--help 时输出用法
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: mcpforge %s\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
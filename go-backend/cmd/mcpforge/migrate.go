@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/database"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/migrations"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status"
+
+// runMigrate 执行 migrate 子命令
+func runMigrate(ctx context.Context, cfg *config.Config, logger *logger.Logger, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	db, err := connectDatabase(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer database.Close(db)
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			logger.Info("Applied migration", "version", m.Version, "name", m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			logger.Info("Database schema is already up to date")
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps <= 0 {
+				return fmt.Errorf("invalid steps %q: %s", args[1], migrateUsage)
+			}
+		}
+		reverted, err := migrator.Down(steps)
+		for _, m := range reverted {
+			logger.Info("Reverted migration", "version", m.Version, "name", m.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate command %q: %s", args[0], migrateUsage)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/app"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/handlers"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/routes"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/tracing"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// tracingFlushTimeout 退出时导出剩余span的最长等待时间
+const tracingFlushTimeout = 5 * time.Second
+
+// runServe 启动HTTP服务和后台任务
+func runServe(ctx context.Context, cfg *config.Config, appLogger *logger.Logger, args []string) error {
+	fs := newFlagSet("serve", "serve")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("serve takes no arguments, got %q", fs.Args())
+	}
+
+	appLogger.Info("Starting MCPForge Backend server", "environment", cfg.Server.Env, "port", cfg.Server.Port)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, cfg.Server.Env)
+	if err != nil {
+		return fmt.Errorf("initialize tracing: %w", err)
+	}
+	// 最后关闭，确保关闭过程中产生的span也被导出
+	defer flushTracing(shutdownTracing, appLogger)
+
+	c, err := newContainer(ctx, cfg, appLogger)
+	if err != nil {
+		return fmt.Errorf("initialize database: %w", err)
+	}
+	defer c.Close()
+
+	// 后台任务在收到退出信号或服务异常退出时停止
+	workerCtx, cancelWorkers := context.WithCancel(ctx)
+	defer cancelWorkers()
+	var workers sync.WaitGroup
+	// 后台清理超过宽限期的已删除账户
+	workers.Go(func() { c.userService.RunAccountPurger(workerCtx, time.Hour, appLogger) })
+	// 后台激活冷静期已结束的收款地址变更
+	workers.Go(func() { c.rewardAddressService.RunActivator(workerCtx, time.Minute, appLogger) })
+	// 后台回收过期的认证用户缓存
+	workers.Go(func() { c.userCache.RunCleanup(workerCtx, time.Minute) })
+	// 后台清理过期的登录挑战
+	workers.Go(func() { c.nonceService.RunCleanup(workerCtx, time.Minute) })
+
+	// 初始化Fiber应用
+	fiberApp := app.New(cfg, appLogger)
+	// 指标和追踪中间件放在最外层，才能统计到被recover转换的panic
+	fiberApp.Use(c.metrics.Middleware())
+	fiberApp.Use(tracing.Middleware())
+	fiberApp.SetupMiddleware()
+
+	// 初始化处理器
+	healthHandler := handlers.NewHealthHandler(cfg, appLogger, c.health)
+	userHandler := handlers.NewUserHandler(cfg, appLogger, c.userService, c.exportService)
+	web3Handler := handlers.NewWeb3Handler(cfg, appLogger, c.userService)
+	rewardHandler := handlers.NewRewardAddressHandler(cfg, appLogger, c.rewardAddressService)
+	roleHandler := handlers.NewRoleApplicationHandler(cfg, appLogger, c.roleApplicationService)
+	profileHandler := handlers.NewProfileHandler(cfg, appLogger, c.profileService)
+	mcpHandler := handlers.NewMCPHandler(cfg, appLogger, c.mcpService)
+	logHandler := handlers.NewLogLevelHandler(cfg, appLogger)
+
+	// 设置路由
+	authMiddleware := middleware.AuthMiddleware(cfg, c.userService)
+	router := routes.NewRoutes(fiberApp, authMiddleware, healthHandler, userHandler, web3Handler, rewardHandler, roleHandler, profileHandler, mcpHandler, logHandler)
+	router.Setup()
+	router.SetupOpenAPI()
+	if cfg.Metrics.Enabled {
+		router.SetupMetrics(c.metrics.Handler(), cfg.Metrics.Token)
+	}
+	if cfg.Server.NodeCompat {
+		router.SetupNodeCompat()
+		appLogger.Info("Node.js API compatibility routes enabled")
+	}
+
+	addr := ":" + cfg.Server.Port
+	appLogger.Info("Server listening on", "address", addr)
+
+	listenErr := make(chan error, 1)
+	go func() { listenErr <- fiberApp.Listen(addr) }()
+
+	select {
+	case err := <-listenErr:
+		// 监听失败时同样要停止后台任务再返回
+		stopWorkers(cancelWorkers, &workers, time.Duration(cfg.Server.ShutdownTimeoutSeconds)*time.Second, appLogger)
+		return err
+	case <-ctx.Done():
+	}
+
+	// 先让就绪检查失败，等待负载均衡摘除实例后再停止接收连接
+	c.health.MarkShuttingDown()
+	if delay := time.Duration(cfg.Server.ShutdownDelaySeconds) * time.Second; delay > 0 {
+		appLogger.Info("Shutdown signal received, waiting before draining", "delay", delay.String())
+		time.Sleep(delay)
+	}
+
+	timeout := time.Duration(cfg.Server.ShutdownTimeoutSeconds) * time.Second
+	deadline := time.Now().Add(timeout)
+	appLogger.Info("Draining requests", "timeout", timeout.String())
+
+	if err := fiberApp.ShutdownWithTimeout(timeout); err != nil {
+		appLogger.Error("HTTP server did not shut down cleanly", "error", err.Error())
+	}
+	stopWorkers(cancelWorkers, &workers, time.Until(deadline), appLogger)
+
+	appLogger.Info("Server stopped")
+	return nil
+}
+
+// flushTracing 导出缓冲中的span，导出端不可用时最多等待 tracingFlushTimeout
+func flushTracing(shutdown tracing.ShutdownFunc, l *logger.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		l.Warn("Failed to flush traces", "error", err.Error())
+	}
+}
+
+// stopWorkers 取消后台任务并等待其退出，超过timeout则放弃等待
+func stopWorkers(cancel context.CancelFunc, workers *sync.WaitGroup, timeout time.Duration, l *logger.Logger) {
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(max(timeout, 0)):
+		l.Warn("Background workers did not stop before the shutdown deadline")
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/database"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/health"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/metrics"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/migrations"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/tracing"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// 启动时数据库连接重试的退避区间
+const (
+	initialConnectBackoff = 500 * time.Millisecond
+	maxConnectBackoff     = 15 * time.Second
+)
+
+// container 各子命令共享的依赖
+type container struct {
+	cfg    *config.Config
+	logger *logger.Logger
+	db     *gorm.DB
+
+	userRepo            repositories.UserRepository
+	rewardAddressRepo   repositories.RewardAddressRepository
+	roleApplicationRepo repositories.RoleApplicationRepository
+	mcpRepo             repositories.MCPRepository
+
+	nonceService           *services.NonceService
+	web3Service            *services.Web3Service
+	userCache              *services.UserCache
+	notifier               services.Notifier
+	userService            *services.UserService
+	rewardAddressService   *services.RewardAddressService
+	roleApplicationService *services.RoleApplicationService
+	profileService         *services.ProfileService
+	mcpService             *services.MCPService
+	exportService          *services.ExportService
+
+	health  *health.Registry
+	metrics *metrics.Metrics
+}
+
+// newContainer 连接数据库并确认结构为最新，然后组装仓储与服务
+func newContainer(ctx context.Context, cfg *config.Config, logger *logger.Logger) (*container, error) {
+	db, err := initDatabase(ctx, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &container{
+		cfg:     cfg,
+		logger:  logger,
+		db:      db,
+		metrics: metrics.New(),
+	}
+	for _, plugin := range []gorm.Plugin{c.metrics.GormPlugin(), tracing.GormPlugin()} {
+		if err := db.Use(plugin); err != nil {
+			database.Close(db)
+			return nil, err
+		}
+	}
+
+	c.userRepo = repositories.NewUserRepository(db)
+	c.rewardAddressRepo = repositories.NewRewardAddressRepository(db)
+	c.roleApplicationRepo = repositories.NewRoleApplicationRepository(db)
+	c.mcpRepo = repositories.NewMCPRepository(db)
+
+	c.nonceService = services.NewNonceService()
+	c.web3Service = services.NewWeb3Service()
+	c.userCache = services.NewUserCache(time.Duration(cfg.Auth.CacheTTLSeconds) * time.Second)
+	if cfg.Mail.Host != "" {
+		c.notifier = services.NewMailNotifier(cfg.Mail, logger)
+	} else {
+		// 生产环境必须配置邮件，见 config.validateProduction
+		c.notifier = services.NewLogNotifier(logger)
+	}
+
+	deletionGracePeriod := time.Duration(cfg.Account.DeletionGraceDays) * 24 * time.Hour
+	c.userService = services.NewUserService(c.userRepo, c.nonceService, c.web3Service, c.userCache, c.metrics, deletionGracePeriod)
+	rewardCoolingPeriod := time.Duration(cfg.Account.RewardCoolingHours) * time.Hour
+	c.rewardAddressService = services.NewRewardAddressService(c.rewardAddressRepo, c.userRepo, c.nonceService, c.web3Service, c.userCache, c.notifier, rewardCoolingPeriod)
+	c.roleApplicationService = services.NewRoleApplicationService(c.roleApplicationRepo, c.userRepo, c.userCache, c.notifier)
+	c.profileService = services.NewProfileService(c.userRepo, c.mcpRepo)
+	c.mcpService = services.NewMCPService(c.mcpRepo)
+	c.exportService = services.NewExportService(c.userRepo, c.rewardAddressRepo, c.roleApplicationRepo, c.mcpRepo)
+
+	nonceService := c.nonceService
+	c.metrics.RegisterGaugeFunc("auth_nonces_active", "Login challenges waiting to be signed.", func() float64 {
+		return float64(nonceService.Len())
+	})
+	c.metrics.Register(metrics.NewBusinessCollector(repositories.NewStatsRepository(db), 30*time.Second))
+
+	c.health = health.NewRegistry()
+	if err := c.registerHealthChecks(); err != nil {
+		database.Close(db)
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// registerHealthChecks 注册各组件的健康检查，未配置的外部依赖不注册
+func (c *container) registerHealthChecks() error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	c.health.Register(health.Check{Name: "database", Critical: true, Run: health.Database(sqlDB)})
+
+	nonceService := c.nonceService
+	c.health.Register(health.Check{
+		Name:     "nonce-store",
+		Timeout:  time.Second,
+		Critical: true,
+		Liveness: true,
+		Run: func(context.Context) error {
+			return nonceService.Probe()
+		},
+	})
+
+	cfg := c.cfg
+	if cfg.Chain.RPCURL != "" {
+		c.health.Register(health.Check{Name: "chain-rpc", Timeout: 5 * time.Second, Run: health.ChainRPC(cfg.Chain.RPCURL, cfg.Chain.ChainID)})
+	}
+	if cfg.Kubernetes.APIHost != "" {
+		c.health.Register(health.Check{Name: "kubernetes", Timeout: 5 * time.Second, Run: health.Kubernetes(cfg.Kubernetes.APIHost, cfg.Kubernetes.BearerToken)})
+	}
+	if cfg.Mail.Host != "" {
+		c.health.Register(health.Check{Name: "mail", Run: health.TCP(net.JoinHostPort(cfg.Mail.Host, strconv.Itoa(cfg.Mail.Port)))})
+	}
+	return nil
+}
+
+// Close 关闭数据库连接池
+func (c *container) Close() {
+	database.Close(c.db)
+}
+
+// initDatabase 初始化数据库连接并确认数据库结构已是最新
+func initDatabase(ctx context.Context, cfg *config.Config, logger *logger.Logger) (*gorm.DB, error) {
+	db, err := connectDatabase(ctx, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		database.Close(db)
+		return nil, err
+	}
+	if err := migrator.EnsureUpToDate(); err != nil {
+		logger.Error("Database schema is out of date", "error", err.Error())
+		database.Close(db)
+		return nil, err
+	}
+
+	logger.Info("Database connected, schema is up to date")
+	return db, nil
+}
+
+// connectDatabase 打开数据库连接，数据库尚未就绪时按指数退避重试
+func connectDatabase(ctx context.Context, cfg *config.Config, logger *logger.Logger) (*gorm.DB, error) {
+	backoff := initialConnectBackoff
+	for attempt := 1; ; attempt++ {
+		db, err := database.Open(cfg.Database)
+		if err == nil {
+			return db, nil
+		}
+		if attempt > cfg.Database.ConnectRetries {
+			return nil, fmt.Errorf("connect database after %d attempt(s): %w", attempt, err)
+		}
+
+		logger.Warn("Database not ready, retrying", "attempt", attempt, "retry_in", backoff.String(), "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
@@ -0,0 +1,668 @@
+package apitest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+)
+
+// MemoryStore 测试用的内存仓储，行为与GORM实现保持一致。
+// 各仓储共享同一份数据，例如审核通过申请会同时修改用户角色
+type MemoryStore struct {
+	mu sync.Mutex
+
+	users        map[uint]models.User
+	authMethods  map[uint]models.AuthMethod
+	changes      map[uint]models.RewardAddressChange
+	applications map[uint]models.RoleApplication
+	events       map[uint]models.RoleApplicationEvent
+	servers      map[uint]models.MCPServer
+	cards        map[uint]models.MCPCard
+	nextID       uint
+}
+
+// NewMemoryStore 创建空的内存仓储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users:        make(map[uint]models.User),
+		authMethods:  make(map[uint]models.AuthMethod),
+		changes:      make(map[uint]models.RewardAddressChange),
+		applications: make(map[uint]models.RoleApplication),
+		events:       make(map[uint]models.RoleApplicationEvent),
+		servers:      make(map[uint]models.MCPServer),
+		cards:        make(map[uint]models.MCPCard),
+	}
+}
+
+// UserRepository 用户仓储
+func (s *MemoryStore) UserRepository() repositories.UserRepository {
+	return &memoryUserRepo{s: s}
+}
+
+// RewardAddressRepository 收款地址变更仓储
+func (s *MemoryStore) RewardAddressRepository() repositories.RewardAddressRepository {
+	return &memoryRewardRepo{s: s}
+}
+
+// RoleApplicationRepository 角色申请仓储
+func (s *MemoryStore) RoleApplicationRepository() repositories.RoleApplicationRepository {
+	return &memoryRoleRepo{s: s}
+}
+
+// MCPRepository MCP服务器和卡片仓储
+func (s *MemoryStore) MCPRepository() repositories.MCPRepository {
+	return &memoryMCPRepo{s: s}
+}
+
+// AddMCPServer 写入MCP服务器，服务器由部署流程创建，没有对应的接口
+func (s *MemoryStore) AddMCPServer(server models.MCPServer) models.MCPServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	server.ID = s.id()
+	server.CreatedAt = time.Now()
+	server.UpdatedAt = server.CreatedAt
+	s.servers[server.ID] = server
+	return server
+}
+
+// AddMCPCard 写入MCP卡片
+func (s *MemoryStore) AddMCPCard(card models.MCPCard) models.MCPCard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	card.ID = s.id()
+	card.CreatedAt = time.Now()
+	card.UpdatedAt = card.CreatedAt
+	s.cards[card.ID] = card
+	return card
+}
+
+func (s *MemoryStore) id() uint {
+	s.nextID++
+	return s.nextID
+}
+
+// memoryUserRepo repositories.UserRepository 的内存实现
+type memoryUserRepo struct {
+	s *MemoryStore
+	// inTx 事务内已持有锁
+	inTx bool
+}
+
+func (r *memoryUserRepo) lock() func() {
+	if r.inTx {
+		return func() {}
+	}
+	r.s.mu.Lock()
+	return r.s.mu.Unlock
+}
+
+// WithTx 出错时恢复执行前的用户和认证方法
+func (r *memoryUserRepo) WithTx(fn func(repo repositories.UserRepository) error) error {
+	defer r.lock()()
+	users, methods, nextID := maps.Clone(r.s.users), maps.Clone(r.s.authMethods), r.s.nextID
+	if err := fn(&memoryUserRepo{s: r.s, inTx: true}); err != nil {
+		r.s.users, r.s.authMethods, r.s.nextID = users, methods, nextID
+		return err
+	}
+	return nil
+}
+
+func (r *memoryUserRepo) WithContext(context.Context) repositories.UserRepository {
+	return r
+}
+
+func (r *memoryUserRepo) Create(user *models.User) error {
+	defer r.lock()()
+	for _, u := range r.s.users {
+		if u.Username == user.Username {
+			return repositories.ErrDuplicateKey
+		}
+	}
+	user.UserID = r.s.id()
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+	r.s.users[user.UserID] = *user
+	return nil
+}
+
+// withMethods 附加认证方法，对应GORM实现的Preload
+func (r *memoryUserRepo) withMethods(user models.User) *models.User {
+	user.AuthMethods = nil
+	for _, id := range slices.Sorted(maps.Keys(r.s.authMethods)) {
+		if m := r.s.authMethods[id]; m.UserID == user.UserID {
+			user.AuthMethods = append(user.AuthMethods, m)
+		}
+	}
+	return &user
+}
+
+func (r *memoryUserRepo) FindByID(id uint) (*models.User, error) {
+	defer r.lock()()
+	user, ok := r.s.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil, apperrors.ErrUserNotFound
+	}
+	return r.withMethods(user), nil
+}
+
+// List 支持角色、认证方法和前缀过滤，游标为"排序字段:方向:下一页偏移量"
+func (r *memoryUserRepo) List(query *models.UserListQuery) (*models.UserPage, error) {
+	defer r.lock()()
+
+	var users []models.User
+	for _, user := range r.s.users {
+		if user.DeletedAt.Valid || !r.matches(user, query) {
+			continue
+		}
+		users = append(users, *r.withMethods(user))
+	}
+	slices.SortFunc(users, func(a, b models.User) int {
+		c := 0
+		switch query.SortBy {
+		case models.UserSortByUsername:
+			c = strings.Compare(a.Username, b.Username)
+		case models.UserSortByCreatedAt:
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		case models.UserSortByUpdatedAt:
+			c = a.UpdatedAt.Compare(b.UpdatedAt)
+		}
+		if c == 0 {
+			c = int(a.UserID) - int(b.UserID)
+		}
+		if query.Order == models.SortDesc {
+			c = -c
+		}
+		return c
+	})
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = repositories.DefaultUserPageSize
+	}
+	limit = min(limit, repositories.MaxUserPageSize)
+
+	sortBy := query.SortBy
+	if !sortBy.IsValid() {
+		sortBy = models.UserSortByID
+	}
+	order := models.SortAsc
+	if query.Order == models.SortDesc {
+		order = models.SortDesc
+	}
+	prefix := fmt.Sprintf("%s:%s:", sortBy, order)
+
+	page := &models.UserPage{Pagination: models.Pagination{Total: int64(len(users)), Limit: limit}}
+	offset := query.Offset
+	if query.Cursor != "" {
+		parts := strings.Split(query.Cursor, ":")
+		if len(parts) != 3 {
+			return nil, apperrors.ErrInvalidCursor
+		}
+		n, err := strconv.Atoi(parts[2])
+		if err != nil || n < 0 {
+			return nil, apperrors.ErrInvalidCursor
+		}
+		if !strings.HasPrefix(query.Cursor, prefix) {
+			return nil, apperrors.Field("cursor", fmt.Sprintf("was issued for sort=%s order=%s; repeat the query with the same sort and order", parts[0], parts[1]))
+		}
+		offset = n
+	} else {
+		page.Pagination.Offset = offset
+	}
+
+	end := min(offset+limit, len(users))
+	if offset < end {
+		page.Users = users[offset:end]
+	}
+	if end < len(users) {
+		page.Pagination.NextCursor = prefix + strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func (r *memoryUserRepo) matches(user models.User, query *models.UserListQuery) bool {
+	if query.Role != nil && user.Role != *query.Role {
+		return false
+	}
+	if query.AuthType != nil || query.AuthIdentifier != nil {
+		found := false
+		for _, m := range r.s.authMethods {
+			if m.UserID == user.UserID &&
+				(query.AuthType == nil || m.AuthType == *query.AuthType) &&
+				(query.AuthIdentifier == nil || m.AuthIdentifier == *query.AuthIdentifier) {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if query.CreatedAfter != nil && user.CreatedAt.Before(*query.CreatedAfter) {
+		return false
+	}
+	if query.CreatedBefore != nil && !user.CreatedAt.Before(*query.CreatedBefore) {
+		return false
+	}
+	if query.UsernamePrefix != nil && !strings.HasPrefix(strings.ToLower(user.Username), strings.ToLower(*query.UsernamePrefix)) {
+		return false
+	}
+	if query.EmailPrefix != nil && (user.Email == nil || !strings.HasPrefix(strings.ToLower(*user.Email), strings.ToLower(*query.EmailPrefix))) {
+		return false
+	}
+	return true
+}
+
+func (r *memoryUserRepo) Update(user *models.User) error {
+	defer r.lock()()
+	for _, u := range r.s.users {
+		if u.Username == user.Username && u.UserID != user.UserID {
+			return repositories.ErrDuplicateKey
+		}
+	}
+	user.UpdatedAt = time.Now()
+	stored := *user
+	stored.AuthMethods = nil
+	r.s.users[user.UserID] = stored
+	return nil
+}
+
+func (r *memoryUserRepo) Delete(id uint) error {
+	defer r.lock()()
+	if user, ok := r.s.users[id]; ok && !user.DeletedAt.Valid {
+		user.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		r.s.users[id] = user
+	}
+	return nil
+}
+
+func (r *memoryUserRepo) findMethod(authType models.AuthType, authIdentifier string) (models.AuthMethod, bool) {
+	for _, m := range r.s.authMethods {
+		if m.AuthType == authType && m.AuthIdentifier == authIdentifier {
+			return m, true
+		}
+	}
+	return models.AuthMethod{}, false
+}
+
+func (r *memoryUserRepo) FindByAuthMethod(authType models.AuthType, authIdentifier string) (*models.User, error) {
+	defer r.lock()()
+	m, ok := r.findMethod(authType, authIdentifier)
+	if !ok {
+		return nil, nil
+	}
+	user, ok := r.s.users[m.UserID]
+	if !ok || user.DeletedAt.Valid {
+		return nil, nil
+	}
+	return r.withMethods(user), nil
+}
+
+func (r *memoryUserRepo) CreateAuthMethod(authMethod *models.AuthMethod) error {
+	defer r.lock()()
+	if _, ok := r.findMethod(authMethod.AuthType, authMethod.AuthIdentifier); ok {
+		return repositories.ErrDuplicateKey
+	}
+	authMethod.AuthID = r.s.id()
+	authMethod.CreatedAt = time.Now()
+	r.s.authMethods[authMethod.AuthID] = *authMethod
+	return nil
+}
+
+func (r *memoryUserRepo) FindByUsername(username string) (*models.User, error) {
+	defer r.lock()()
+	for _, user := range r.s.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryUserRepo) FindDeletedByAuthMethod(authType models.AuthType, authIdentifier string) (*models.User, error) {
+	defer r.lock()()
+	m, ok := r.findMethod(authType, authIdentifier)
+	if !ok {
+		return nil, nil
+	}
+	user, ok := r.s.users[m.UserID]
+	if !ok || !user.DeletedAt.Valid || user.PurgedAt != nil {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+func (r *memoryUserRepo) Restore(id uint) error {
+	defer r.lock()()
+	user, ok := r.s.users[id]
+	if !ok || !user.DeletedAt.Valid || user.PurgedAt != nil {
+		return apperrors.ErrUserNotFound
+	}
+	user.DeletedAt = gorm.DeletedAt{}
+	r.s.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepo) FindPurgeable(deletedBefore time.Time, limit int) ([]models.User, error) {
+	defer r.lock()()
+	var users []models.User
+	for _, id := range slices.Sorted(maps.Keys(r.s.users)) {
+		user := r.s.users[id]
+		if user.DeletedAt.Valid && user.DeletedAt.Time.Before(deletedBefore) && user.PurgedAt == nil && len(users) < limit {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *memoryUserRepo) Purge(id uint) error {
+	defer r.lock()()
+	for authID, m := range r.s.authMethods {
+		if m.UserID == id {
+			delete(r.s.authMethods, authID)
+		}
+	}
+	user := r.s.users[id]
+	now := time.Now()
+	r.s.users[id] = models.User{
+		UserID:    id,
+		Username:  "deleted-user-" + strconv.Itoa(int(id)),
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: now,
+		DeletedAt: user.DeletedAt,
+		PurgedAt:  &now,
+	}
+	return nil
+}
+
+// memoryRewardRepo repositories.RewardAddressRepository 的内存实现
+type memoryRewardRepo struct{ s *MemoryStore }
+
+func (r *memoryRewardRepo) ReplacePending(change *models.RewardAddressChange) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	for id, c := range r.s.changes {
+		if c.UserID == change.UserID && c.Status == models.RewardAddressPending {
+			c.Status, c.CancelledAt = models.RewardAddressCancelled, &now
+			r.s.changes[id] = c
+		}
+	}
+	change.ID = r.s.id()
+	change.CreatedAt, change.UpdatedAt = now, now
+	r.s.changes[change.ID] = *change
+	return nil
+}
+
+func (r *memoryRewardRepo) FindPending(userID uint) (*models.RewardAddressChange, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, c := range r.s.changes {
+		if c.UserID == userID && c.Status == models.RewardAddressPending {
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRewardRepo) ListByUser(userID uint) ([]models.RewardAddressChange, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var changes []models.RewardAddressChange
+	for _, id := range slices.Backward(slices.Sorted(maps.Keys(r.s.changes))) {
+		if c := r.s.changes[id]; c.UserID == userID {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+func (r *memoryRewardRepo) CancelPending(userID uint) (*models.RewardAddressChange, error) {
+	change, _ := r.FindPending(userID)
+	if change == nil {
+		return nil, nil
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	change.Status, change.CancelledAt = models.RewardAddressCancelled, &now
+	r.s.changes[change.ID] = *change
+	return change, nil
+}
+
+func (r *memoryRewardRepo) FindDue(now time.Time, limit int) ([]models.RewardAddressChange, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var changes []models.RewardAddressChange
+	for _, id := range slices.Sorted(maps.Keys(r.s.changes)) {
+		c := r.s.changes[id]
+		if c.Status == models.RewardAddressPending && !c.EffectiveAt.After(now) && len(changes) < limit {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+func (r *memoryRewardRepo) Activate(change *models.RewardAddressChange) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for id, c := range r.s.changes {
+		if c.UserID == change.UserID && c.Status == models.RewardAddressActive {
+			c.Status = models.RewardAddressSuperseded
+			r.s.changes[id] = c
+		}
+	}
+	now := time.Now()
+	change.Status, change.ActivatedAt = models.RewardAddressActive, &now
+	r.s.changes[change.ID] = *change
+
+	user := r.s.users[change.UserID]
+	user.RewardAddress = &change.Address
+	r.s.users[change.UserID] = user
+	return nil
+}
+
+// memoryRoleRepo repositories.RoleApplicationRepository 的内存实现
+type memoryRoleRepo struct{ s *MemoryStore }
+
+func (r *memoryRoleRepo) Create(application *models.RoleApplication, actorID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	application.ID = r.s.id()
+	application.CreatedAt, application.UpdatedAt = now, now
+	r.s.applications[application.ID] = *application
+
+	event := models.RoleApplicationEvent{ID: r.s.id(), ApplicationID: application.ID, ActorID: actorID, Action: models.RoleApplicationSubmitted, CreatedAt: now}
+	r.s.events[event.ID] = event
+	return nil
+}
+
+// withEvents 附加审计事件，对应GORM实现的Preload
+func (r *memoryRoleRepo) withEvents(application models.RoleApplication) models.RoleApplication {
+	application.Events = nil
+	for _, id := range slices.Sorted(maps.Keys(r.s.events)) {
+		if e := r.s.events[id]; e.ApplicationID == application.ID {
+			application.Events = append(application.Events, e)
+		}
+	}
+	return application
+}
+
+func (r *memoryRoleRepo) FindByID(id uint) (*models.RoleApplication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	application, ok := r.s.applications[id]
+	if !ok {
+		return nil, apperrors.ErrRoleApplicationNotFound
+	}
+	application = r.withEvents(application)
+	return &application, nil
+}
+
+func (r *memoryRoleRepo) FindPendingByUser(userID uint) (*models.RoleApplication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, a := range r.s.applications {
+		if a.UserID == userID && a.Status == models.RoleApplicationPending {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryRoleRepo) ListByUser(userID uint) ([]models.RoleApplication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var applications []models.RoleApplication
+	for _, id := range slices.Backward(slices.Sorted(maps.Keys(r.s.applications))) {
+		if a := r.s.applications[id]; a.UserID == userID {
+			applications = append(applications, r.withEvents(a))
+		}
+	}
+	return applications, nil
+}
+
+func (r *memoryRoleRepo) List(status *models.RoleApplicationStatus) ([]models.RoleApplication, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var applications []models.RoleApplication
+	for _, id := range slices.Sorted(maps.Keys(r.s.applications)) {
+		if a := r.s.applications[id]; status == nil || a.Status == *status {
+			applications = append(applications, a)
+		}
+	}
+	return applications, nil
+}
+
+func (r *memoryRoleRepo) Review(application *models.RoleApplication, event *models.RoleApplicationEvent) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.applications[application.ID]
+	if !ok || stored.Status != models.RoleApplicationPending {
+		return apperrors.ErrRoleApplicationReviewed
+	}
+	stored.Status, stored.ReviewerID, stored.ReviewNotes, stored.ReviewedAt = application.Status, application.ReviewerID, application.ReviewNotes, application.ReviewedAt
+	r.s.applications[application.ID] = stored
+
+	event.ID = r.s.id()
+	event.CreatedAt = time.Now()
+	r.s.events[event.ID] = *event
+
+	if application.Status == models.RoleApplicationApproved {
+		user := r.s.users[application.UserID]
+		user.Role = application.RequestedRole
+		user.GithubHandle = &application.GithubHandle
+		r.s.users[application.UserID] = user
+	}
+	return nil
+}
+
+func (r *memoryRoleRepo) Provision(application *models.RoleApplication, event *models.RoleApplicationEvent) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	application.ID = r.s.id()
+	application.CreatedAt, application.UpdatedAt = now, now
+	r.s.applications[application.ID] = *application
+
+	event.ID = r.s.id()
+	event.ApplicationID = application.ID
+	event.CreatedAt = now
+	r.s.events[event.ID] = *event
+
+	user := r.s.users[application.UserID]
+	user.Role = application.RequestedRole
+	r.s.users[application.UserID] = user
+	return nil
+}
+
+// memoryMCPRepo repositories.MCPRepository 的内存实现
+type memoryMCPRepo struct {
+	s *MemoryStore
+}
+
+// newestFirst 与GORM实现相同，按创建时间和ID倒序
+func newestFirst[T any](items []T, key func(T) (time.Time, uint)) []T {
+	slices.SortFunc(items, func(a, b T) int {
+		at, aid := key(a)
+		bt, bid := key(b)
+		if c := bt.Compare(at); c != 0 {
+			return c
+		}
+		return int(bid) - int(aid)
+	})
+	return items
+}
+
+func serverKey(s models.MCPServer) (time.Time, uint) { return s.CreatedAt, s.ID }
+func cardKey(c models.MCPCard) (time.Time, uint)     { return c.CreatedAt, c.ID }
+
+func (r *memoryMCPRepo) ListServers() ([]models.MCPServer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return newestFirst(slices.Collect(maps.Values(r.s.servers)), serverKey), nil
+}
+
+func (r *memoryMCPRepo) FindServerByName(name string) (*models.MCPServer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, server := range r.s.servers {
+		if server.Name == name {
+			return &server, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryMCPRepo) ListServersByOwner(ownerID uint) ([]models.MCPServer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var servers []models.MCPServer
+	for _, server := range r.s.servers {
+		if server.OwnerID != nil && *server.OwnerID == ownerID {
+			servers = append(servers, server)
+		}
+	}
+	return newestFirst(servers, serverKey), nil
+}
+
+func (r *memoryMCPRepo) ListCards() ([]models.MCPCard, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return newestFirst(slices.Collect(maps.Values(r.s.cards)), cardKey), nil
+}
+
+func (r *memoryMCPRepo) FindCardByID(id uint) (*models.MCPCard, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	card, ok := r.s.cards[id]
+	if !ok {
+		return nil, nil
+	}
+	return &card, nil
+}
+
+func (r *memoryMCPRepo) ListCardsByOwner(ownerID uint) ([]models.MCPCard, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var cards []models.MCPCard
+	for _, card := range r.s.cards {
+		if card.OwnerID != nil && *card.OwnerID == ownerID {
+			cards = append(cards, card)
+		}
+	}
+	return newestFirst(cards, cardKey), nil
+}
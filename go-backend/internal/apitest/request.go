@@ -0,0 +1,194 @@
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// Response 已读取完响应体的HTTP响应
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Cookies    []*http.Cookie
+	Body       []byte
+}
+
+// envelope 成功和错误响应共用的信封
+type envelope struct {
+	Success    bool                   `json:"success"`
+	Data       json.RawMessage        `json:"data"`
+	Pagination *models.Pagination     `json:"pagination"`
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	Details    []apperrors.FieldError `json:"details"`
+}
+
+// Do 发送请求。body 为 string 或 []byte 时原样发送，否则编码为JSON；token 非空时作为Bearer令牌
+func (s *Server) Do(t testing.TB, method, path string, body any, token string) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(b)
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, s.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Cookies: resp.Cookies(), Body: data}
+}
+
+func (r *Response) envelope(t testing.TB) envelope {
+	t.Helper()
+	var env envelope
+	if err := json.Unmarshal(r.Body, &env); err != nil {
+		t.Fatalf("decode response (status %d): %v\n%s", r.StatusCode, err, r.Body)
+	}
+	return env
+}
+
+// Decode 确认请求成功并把 data 解码到out，out 为nil时只检查状态
+func (r *Response) Decode(t testing.TB, out any) {
+	t.Helper()
+	env := r.envelope(t)
+	if r.StatusCode >= 300 || !env.Success {
+		t.Fatalf("expected success, got status %d: %s", r.StatusCode, r.Body)
+	}
+	if out == nil {
+		return
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		t.Fatalf("decode data: %v\n%s", err, env.Data)
+	}
+}
+
+// Pagination 列表响应的分页信息
+func (r *Response) Pagination(t testing.TB) *models.Pagination {
+	t.Helper()
+	return r.envelope(t).Pagination
+}
+
+// ExpectError 确认响应为want对应的状态码和错误码，返回字段级错误
+func (r *Response) ExpectError(t testing.TB, want *apperrors.Error) []apperrors.FieldError {
+	t.Helper()
+	env := r.envelope(t)
+	if r.StatusCode != want.Status || env.Success || env.Code != string(want.Code) {
+		t.Fatalf("expected %d %s, got status %d: %s", want.Status, want.Code, r.StatusCode, r.Body)
+	}
+	return env.Details
+}
+
+// Cookie 响应设置的指定cookie
+func (r *Response) Cookie(name string) *http.Cookie {
+	for _, c := range r.Cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// Challenge 获取登录挑战
+func (s *Server) Challenge(t testing.TB, address string) string {
+	t.Helper()
+	var challenge models.Web3ChallengeResponse
+	s.Do(t, http.MethodGet, "/api/v1/user/auth/web3/challenge?address="+url.QueryEscape(address), nil, "").Decode(t, &challenge)
+	return challenge.Nonce
+}
+
+// Authenticate 获取挑战、签名并提交认证，返回原始响应以便检查失败情况。
+// req 中的地址、nonce和签名会被覆盖
+func (s *Server) Authenticate(t testing.TB, w *Wallet, req models.Web3AuthRequest) *Response {
+	t.Helper()
+	req.Address = w.Address
+	req.Nonce = s.Challenge(t, w.Address)
+	req.Signature = w.Sign(t, req.Nonce)
+	return s.Do(t, http.MethodPost, "/api/v1/user/auth/web3/verify", req, "")
+}
+
+// Session 已登录的用户
+type Session struct {
+	server *Server
+
+	Token  string
+	Action string
+	User   models.User
+}
+
+// Login 用钱包登录，首次登录时注册。req 可设置注册时的用户名、邮箱或恢复账户
+func (s *Server) Login(t testing.TB, w *Wallet, req models.Web3AuthRequest) *Session {
+	t.Helper()
+	resp := s.Authenticate(t, w, req)
+	var auth models.Web3AuthResponse
+	resp.Decode(t, &auth)
+
+	cookie := resp.Cookie(middleware.AuthCookieName)
+	if cookie == nil || cookie.Value == "" {
+		t.Fatalf("login did not set the %s cookie", middleware.AuthCookieName)
+	}
+	return &Session{server: s, Token: cookie.Value, Action: auth.Action, User: auth.User}
+}
+
+// Provision 与运维命令相同，按钱包地址创建用户并设置角色
+func (s *Server) Provision(t testing.TB, username, address string, role models.UserRole) *models.User {
+	t.Helper()
+	user, _, err := s.Users.ProvisionWeb3User(context.Background(), username, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Roles.Provision(user.UserID, role, "apitest"); err != nil {
+		t.Fatal(err)
+	}
+	user.Role = role
+	return user
+}
+
+// LoginAs 以指定角色预置用户后登录，用于准备管理员等无法通过接口注册的账户
+func (s *Server) LoginAs(t testing.TB, username string, role models.UserRole) (*Session, *Wallet) {
+	t.Helper()
+	w := NewWallet(t)
+	s.Provision(t, username, w.Address, role)
+	return s.Login(t, w, models.Web3AuthRequest{}), w
+}
+
+// Do 以该用户身份发送请求
+func (sess *Session) Do(t testing.TB, method, path string, body any) *Response {
+	t.Helper()
+	return sess.server.Do(t, method, path, body, sess.Token)
+}
@@ -0,0 +1,91 @@
+// Package apitest 端到端测试工具：用内存仓储组装完整的应用，
+// 并提供钱包签名、登录和发送请求的辅助函数
+package apitest
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/app"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/handlers"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/health"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/routes"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// Server 通过 app.New 和 routes.Setup 组装、与生产相同路由和中间件的测试服务
+type Server struct {
+	*httptest.Server
+
+	Config *config.Config
+	App    *app.App
+	Store  *MemoryStore
+	Nonces *services.NonceService
+	Users  *services.UserService
+	Roles  *services.RoleApplicationService
+}
+
+// New 启动测试服务，测试结束时关闭。configure 可在组装前修改默认配置
+func New(t testing.TB, configure ...func(cfg *config.Config)) *Server {
+	t.Helper()
+
+	cfg := config.DefaultFor(config.EnvTest)
+	for _, fn := range configure {
+		fn(cfg)
+	}
+	l := logger.New("error")
+
+	store := NewMemoryStore()
+	userRepo := store.UserRepository()
+	rewardRepo := store.RewardAddressRepository()
+	roleRepo := store.RoleApplicationRepository()
+
+	nonceService := services.NewNonceService()
+	web3Service := services.NewWeb3Service()
+	userCache := services.NewUserCache(time.Duration(cfg.Auth.CacheTTLSeconds) * time.Second)
+	notifier := services.NewLogNotifier(l)
+	userService := services.NewUserService(userRepo, nonceService, web3Service, userCache, services.NopAuthMetrics{}, time.Duration(cfg.Account.DeletionGraceDays)*24*time.Hour)
+	rewardService := services.NewRewardAddressService(rewardRepo, userRepo, nonceService, web3Service, userCache, notifier, time.Duration(cfg.Account.RewardCoolingHours)*time.Hour)
+	roleService := services.NewRoleApplicationService(roleRepo, userRepo, userCache, notifier)
+	profileService := services.NewProfileService(userRepo, store.MCPRepository())
+	mcpService := services.NewMCPService(store.MCPRepository())
+	exportService := services.NewExportService(userRepo, rewardRepo, roleRepo, store.MCPRepository())
+
+	a := app.New(cfg, l)
+	a.SetupMiddleware()
+	router := routes.NewRoutes(a,
+		middleware.AuthMiddleware(cfg, userService),
+		handlers.NewHealthHandler(cfg, l, health.NewRegistry()),
+		handlers.NewUserHandler(cfg, l, userService, exportService),
+		handlers.NewWeb3Handler(cfg, l, userService),
+		handlers.NewRewardAddressHandler(cfg, l, rewardService),
+		handlers.NewRoleApplicationHandler(cfg, l, roleService),
+		handlers.NewProfileHandler(cfg, l, profileService),
+		handlers.NewMCPHandler(cfg, l, mcpService),
+		handlers.NewLogLevelHandler(cfg, l),
+	)
+	router.Setup()
+	router.SetupOpenAPI()
+	if cfg.Server.NodeCompat {
+		router.SetupNodeCompat()
+	}
+
+	srv := httptest.NewServer(adaptor.FiberApp(a.App))
+	t.Cleanup(srv.Close)
+
+	return &Server{
+		Server: srv,
+		Config: cfg,
+		App:    a,
+		Store:  store,
+		Nonces: nonceService,
+		Users:  userService,
+		Roles:  roleService,
+	}
+}
@@ -0,0 +1,39 @@
+package apitest
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+)
+
+// Wallet 测试中随机生成的钱包
+type Wallet struct {
+	PrivateKey string
+	Address    string
+}
+
+// NewWallet 生成新的私钥及其地址
+func NewWallet(t testing.TB) *Wallet {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Wallet{
+		PrivateKey: hex.EncodeToString(crypto.FromECDSA(key)),
+		Address:    crypto.PubkeyToAddress(key.PublicKey).Hex(),
+	}
+}
+
+// Sign 用 Web3Service.SignMessage 对消息签名，与钱包的 personal_sign 一致
+func (w *Wallet) Sign(t testing.TB, message string) string {
+	t.Helper()
+	signature, err := services.NewWeb3Service().SignMessage(message, w.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature
+}
@@ -6,7 +6,11 @@ import (
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
 	"github.com/gofiber/fiber/v3/middleware/recover"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/reqctx"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/validation"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
 )
 
@@ -17,45 +21,57 @@ type App struct {
 }
 
 func New(cfg *config.Config, l *logger.Logger) *App {
-	app := fiber.New(fiber.Config{
+	a := &App{
+		config: cfg,
+		logger: l,
+	}
+	a.App = fiber.New(fiber.Config{
 		AppName:      "MCPForge Backend",
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  120 * time.Second,
-		ErrorHandler: defaultErrorHandler,
+		ErrorHandler: a.defaultErrorHandler,
+		// 超出限制的请求体在读取阶段即被拒绝，返回413
+		BodyLimit:   cfg.Security.BodyLimitBytes,
+		JSONDecoder: decodeStrictJSON,
+		// 绑定请求后按DTO的validate标签校验
+		StructValidator: validation.New(),
 	})
 
-	return &App{
-		App:    app,
-		config: cfg,
-		logger: l,
-	}
+	return a
 }
 
 func (a *App) SetupMiddleware() {
+	// 访问日志在recover之外，panic转换成的错误也会被记录
+	a.Use(middleware.RequestID())
+	a.Use(middleware.AccessLog(a.logger, a.config.Log))
 	a.Use(recover.New())
-	a.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Content-Type", "Authorization"},
-		AllowCredentials: true,
-		MaxAge:           86400,
-	}))
-	a.Use(a.logger.Middleware())
+	a.Use(middleware.SecurityHeaders(a.config.Security))
+	// 未配置来源时不注册CORS，fiber 会把空列表当作允许所有来源
+	if len(a.config.CORS.AllowedOrigins) > 0 {
+		a.Use(corsMiddleware(a.config.CORS))
+	}
 }
 
-func defaultErrorHandler(c fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
-	message := "Internal Server Error"
+// corsMiddleware 按配置的来源、方法和请求头处理跨域请求
+func corsMiddleware(cfg config.CORSConfig) fiber.Handler {
+	return cors.New(cors.Config{
+		AllowOrigins:     cfg.AllowedOrigins,
+		AllowMethods:     cfg.AllowedMethods,
+		AllowHeaders:     cfg.AllowedHeaders,
+		ExposeHeaders:    cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAgeSeconds,
+	})
+}
 
-	if e, ok := err.(*fiber.Error); ok {
-		code = e.Code
-		message = e.Message
+// defaultErrorHandler 将处理器返回的错误统一转换为错误信封，
+// 未知错误按500处理且不向客户端暴露原始信息
+func (a *App) defaultErrorHandler(c fiber.Ctx, err error) error {
+	appErr := apperrors.From(err)
+	if appErr.Status >= fiber.StatusInternalServerError {
+		a.logger.WithContext(reqctx.From(c)).Error("Request failed", "method", c.Method(), "path", c.Path(), "error", err.Error())
 	}
 
-	return c.Status(code).JSON(fiber.Map{
-		"success": false,
-		"message": message,
-		"timestamp": time.Now().Unix(),
-	})
+	return writeError(c, appErr)
 }
\ No newline at end of file
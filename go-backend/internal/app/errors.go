@@ -0,0 +1,23 @@
+package app
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+)
+
+// writeError 以统一信封返回业务错误，details 为字段级错误
+func writeError(c fiber.Ctx, err *apperrors.Error) error {
+	body := fiber.Map{
+		"success":   false,
+		"code":      err.Code,
+		"message":   err.Message,
+		"timestamp": time.Now().Unix(),
+	}
+	if len(err.Details) > 0 {
+		body["details"] = err.Details
+	}
+	return c.Status(err.Status).JSON(body)
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// errTrailingData 请求体在JSON值之后还有其他内容
+var errTrailingData = errors.New("unexpected data after JSON value")
+
+// decodeStrictJSON 作为 fiber 的 JSONDecoder 解析请求体：
+// 拒绝DTO中不存在的字段和JSON值之后的多余内容，避免拼写错误或拼接的请求被静默接受
+func decodeStrictJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errTrailingData
+	}
+	return nil
+}
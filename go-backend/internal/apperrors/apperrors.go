@@ -0,0 +1,153 @@
+// Package apperrors 定义业务错误及其对应的HTTP状态码和错误码。
+// 服务层返回这些错误，由统一的错误处理器转换为响应
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// Code 稳定的错误码，客户端据此区分错误，不应依赖message
+type Code string
+
+// 通用错误码，与HTTP状态码对应
+const (
+	CodeBadRequest         Code = "bad_request"
+	CodeValidation         Code = "validation_failed"
+	CodeUnauthorized       Code = "unauthorized"
+	CodeForbidden          Code = "forbidden"
+	CodeNotFound           Code = "not_found"
+	CodeConflict           Code = "conflict"
+	CodeGone               Code = "gone"
+	CodePayloadTooLarge    Code = "payload_too_large"
+	CodeTooManyRequests    Code = "too_many_requests"
+	CodeInternal           Code = "internal_error"
+	CodeServiceUnavailable Code = "service_unavailable"
+)
+
+// FieldError 单个字段的错误
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error 带状态码和错误码的业务错误
+type Error struct {
+	Status  int
+	Code    Code
+	Message string
+	Details []FieldError
+
+	cause error
+}
+
+// New 创建业务错误
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is 错误码相同即视为同一错误，使派生出的错误仍能与哨兵错误匹配
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage 返回替换了message的副本
+func (e *Error) WithMessage(format string, args ...any) *Error {
+	clone := *e
+	clone.Message = fmt.Sprintf(format, args...)
+	return &clone
+}
+
+// WithDetails 返回附加了字段错误的副本
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	clone := *e
+	clone.Details = append(append([]FieldError(nil), e.Details...), details...)
+	return &clone
+}
+
+// Wrap 返回记录了底层原因的副本，原因只用于日志，不返回给客户端
+func (e *Error) Wrap(cause error) *Error {
+	clone := *e
+	clone.cause = cause
+	return &clone
+}
+
+// BadRequest 创建400错误
+func BadRequest(message string) *Error {
+	return New(http.StatusBadRequest, CodeBadRequest, message)
+}
+
+// Validation 创建字段校验错误
+func Validation(details ...FieldError) *Error {
+	return ErrValidation.WithDetails(details...)
+}
+
+// Field 创建单个字段的校验错误
+func Field(field, message string) *Error {
+	return Validation(FieldError{Field: field, Message: message})
+}
+
+// Internal 包装未预期的错误，对客户端隐藏原始信息
+func Internal(cause error) *Error {
+	return ErrInternal.Wrap(cause)
+}
+
+// From 将任意错误转换为业务错误，未知错误视为内部错误
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return New(fiberErr.Code, CodeForStatus(fiberErr.Code), fiberErr.Message)
+	}
+
+	return Internal(err)
+}
+
+// CodeForStatus 返回HTTP状态码对应的通用错误码
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
@@ -0,0 +1,88 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestCodeForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   Code
+	}{
+		{http.StatusBadRequest, CodeBadRequest},
+		{http.StatusUnprocessableEntity, CodeValidation},
+		{http.StatusUnauthorized, CodeUnauthorized},
+		{http.StatusForbidden, CodeForbidden},
+		{http.StatusNotFound, CodeNotFound},
+		{http.StatusConflict, CodeConflict},
+		{http.StatusGone, CodeGone},
+		{http.StatusRequestEntityTooLarge, CodePayloadTooLarge},
+		{http.StatusTooManyRequests, CodeTooManyRequests},
+		{http.StatusServiceUnavailable, CodeServiceUnavailable},
+		{http.StatusInternalServerError, CodeInternal},
+		{http.StatusBadGateway, CodeInternal},
+		// 没有专门错误码的4xx统一为 bad_request
+		{http.StatusMethodNotAllowed, CodeBadRequest},
+		{http.StatusTeapot, CodeBadRequest},
+	}
+	for _, tt := range tests {
+		if got := CodeForStatus(tt.status); got != tt.want {
+			t.Errorf("CodeForStatus(%d) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestFrom(t *testing.T) {
+	cause := errors.New("connection refused")
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    Code
+		wantMessage string
+	}{
+		{"sentinel", ErrUserNotFound, http.StatusNotFound, "user_not_found", "user not found"},
+		{"wrapped sentinel", fmt.Errorf("load user: %w", ErrUsernameTaken), http.StatusConflict, "username_taken", "username already exists"},
+		{"derived", ErrForbidden.WithMessage("admins only"), http.StatusForbidden, CodeForbidden, "admins only"},
+		{"fiber error", fiber.NewError(http.StatusMethodNotAllowed, "Method Not Allowed"), http.StatusMethodNotAllowed, CodeBadRequest, "Method Not Allowed"},
+		{"fiber too large", fiber.ErrRequestEntityTooLarge, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, fiber.ErrRequestEntityTooLarge.Message},
+		{"unknown", cause, http.StatusInternalServerError, CodeInternal, "Internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := From(tt.err)
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode || got.Message != tt.wantMessage {
+				t.Errorf("From(%v) = %d %s %q, want %d %s %q", tt.err, got.Status, got.Code, got.Message, tt.wantStatus, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+
+	// 未知错误的原因保留在错误链中供日志使用，不出现在message里
+	internal := From(cause)
+	if !errors.Is(internal, cause) || !errors.Is(internal, ErrInternal) {
+		t.Errorf("From(unknown) lost its cause or code: %v", internal)
+	}
+}
+
+func TestDerivedErrorsMatchSentinel(t *testing.T) {
+	err := ErrValidation.WithDetails(FieldError{Field: "username", Message: "required"})
+	if !errors.Is(err, ErrValidation) {
+		t.Error("error with details does not match its sentinel")
+	}
+	if errors.Is(err, ErrInvalidBody) {
+		t.Error("errors with different codes match")
+	}
+	if len(ErrValidation.Details) != 0 {
+		t.Errorf("WithDetails modified the sentinel: %+v", ErrValidation.Details)
+	}
+
+	more := err.WithDetails(FieldError{Field: "email", Message: "invalid"})
+	if len(err.Details) != 1 || len(more.Details) != 2 {
+		t.Errorf("details = %+v then %+v", err.Details, more.Details)
+	}
+}
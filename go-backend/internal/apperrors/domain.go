@@ -0,0 +1,56 @@
+package apperrors
+
+import (
+	"net/http"
+)
+
+// 通用错误
+var (
+	ErrValidation     = New(http.StatusBadRequest, CodeValidation, "Validation failed")
+	ErrInvalidBody    = New(http.StatusBadRequest, "invalid_body", "Invalid request body")
+	ErrAuthRequired   = New(http.StatusUnauthorized, CodeUnauthorized, "Authentication required")
+	ErrInvalidToken   = New(http.StatusUnauthorized, "invalid_token", "Invalid or expired token")
+	ErrForbidden      = New(http.StatusForbidden, CodeForbidden, "Insufficient permissions")
+	ErrInternal       = New(http.StatusInternalServerError, CodeInternal, "Internal server error")
+	ErrNotImplemented = New(http.StatusNotImplemented, "not_implemented", "Not implemented")
+)
+
+// 用户与认证
+var (
+	ErrUserNotFound           = New(http.StatusNotFound, "user_not_found", "user not found")
+	ErrUserGone               = New(http.StatusUnauthorized, "user_gone", "User no longer exists")
+	ErrUsernameTaken          = New(http.StatusConflict, "username_taken", "username already exists")
+	ErrAuthMethodExists       = New(http.StatusConflict, "auth_method_exists", "auth method already exists")
+	ErrNotAccountOwner        = New(http.StatusForbidden, "not_account_owner", "You can only access your own account")
+	ErrInvalidCursor          = New(http.StatusBadRequest, "invalid_cursor", "invalid cursor")
+	ErrInvalidAddress         = New(http.StatusBadRequest, "invalid_address", "invalid ethereum address")
+	ErrInvalidNonce           = New(http.StatusUnauthorized, "invalid_nonce", "invalid or expired nonce")
+	ErrInvalidSignature       = New(http.StatusUnauthorized, "invalid_signature", "invalid signature")
+	ErrAccountPendingDeletion = New(http.StatusConflict, "account_pending_deletion", "account is scheduled for deletion, sign in with restore to recover it")
+	ErrDeletionGraceExpired   = New(http.StatusGone, "deletion_grace_expired", "account deletion grace period has expired")
+	ErrRoleReadOnly           = New(http.StatusBadRequest, "role_read_only", "role can only be granted by approving a developer application")
+	ErrProfileNotFound        = New(http.StatusNotFound, "profile_not_found", "Profile not found")
+)
+
+// 收款地址
+var (
+	ErrRewardAddressReadOnly  = New(http.StatusBadRequest, "reward_address_read_only", "reward_address must be changed through the verified reward address flow")
+	ErrRewardAddressUnchanged = New(http.StatusConflict, "reward_address_unchanged", "address is already the current reward address")
+	ErrRewardChangeInProgress = New(http.StatusConflict, "reward_change_in_progress", "another reward address change is in progress")
+	ErrNoPendingRewardChange  = New(http.StatusNotFound, "no_pending_reward_change", "no pending reward address change")
+)
+
+// 开发者角色申请
+var (
+	ErrRoleApplicationNotFound = New(http.StatusNotFound, "role_application_not_found", "role application not found")
+	ErrRoleApplicationReviewed = New(http.StatusConflict, "role_application_reviewed", "role application has already been reviewed")
+	ErrRoleApplicationPending  = New(http.StatusConflict, "role_application_pending", "a developer application is already pending review")
+	ErrRoleAlreadyGranted      = New(http.StatusConflict, "role_already_granted", "user already has an elevated role")
+	ErrSelfReview              = New(http.StatusForbidden, "self_review", "reviewers cannot review their own application")
+)
+
+// MCP服务器和卡片
+var (
+	ErrMCPServerNotFound = New(http.StatusNotFound, "mcp_server_not_found", "MCP server not found")
+	ErrMCPCardNotFound   = New(http.StatusNotFound, "mcp_card_not_found", "MCP card not found")
+)
@@ -0,0 +1,52 @@
+// Package buildinfo 暴露构建时注入的版本信息
+//
+// 发布构建通过 -ldflags 注入，例如:
+//
+//	go build -ldflags "-X github.com/YoubetDao/MCPForge-Backend/go-backend/internal/buildinfo.Version=1.2.0" ./cmd/mcpforge
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// 构建时通过 -ldflags -X 注入
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info 构建信息
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get 返回构建信息，未注入提交时使用Go工具链记录的VCS信息
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = s.Value
+				}
+			}
+		}
+	}
+	return info
+}
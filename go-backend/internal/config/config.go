@@ -1,57 +1,306 @@
 package config
 
 import (
-	"log"
-	"os"
-	"strconv"
+	"fmt"
+	"net/url"
+	"strings"
+)
 
-	"github.com/joho/godotenv"
+// 运行环境
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
 )
 
+// insecureJWTSecret 仅供本地开发使用的JWT密钥，生产环境拒绝启动
+const insecureJWTSecret = "insecure-development-secret"
+
+// Config 应用配置，按 默认值 -> YAML文件 -> 环境变量 -> 命令行参数 的顺序加载
+//
+// 每个字段的 yaml 标签同时决定命令行参数名（如 database.host、auth.jwt-secret），
+// env 标签列出可读取的环境变量，排在前面的优先；secret 标签的字段在打印时会被隐藏。
 type Config struct {
-	ServerPort     string
-	Env            string
-	DBHost         string
-	DBPort         string
-	DBUser         string
-	DBPassword     string
-	DBName         string
-	JWTSecret      string
-	JWTExpiresIn   int
-	LogLevel       string
+	Server     ServerConfig     `yaml:"server"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Account    AccountConfig    `yaml:"account"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Security   SecurityConfig   `yaml:"security"`
+	Chain      ChainConfig      `yaml:"chain"`
+	Kubernetes KubernetesConfig `yaml:"kubernetes"`
+	Mail       MailConfig       `yaml:"mail"`
+	Log        LogConfig        `yaml:"log"`
+	Metrics    MetricsConfig    `yaml:"metrics"`
+	Tracing    TracingConfig    `yaml:"tracing"`
 }
 
-func Load() *Config {
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
-	}
+// ServerConfig HTTP服务配置
+type ServerConfig struct {
+	Port       string `yaml:"port" env:"PORT" usage:"HTTP listen port"`
+	Env        string `yaml:"env" env:"ENV,NODE_ENV" usage:"runtime environment: development, test, staging or production"`
+	NodeCompat bool   `yaml:"node_compat" env:"NODE_COMPAT" usage:"enable Node.js compatible routes"`
+	// ShutdownTimeoutSeconds 收到退出信号后等待请求和后台任务结束的最长时间
+	ShutdownTimeoutSeconds int `yaml:"shutdown_timeout_seconds" env:"SHUTDOWN_TIMEOUT_SECONDS" usage:"seconds to drain requests and stop workers on shutdown"`
+	// ShutdownDelaySeconds 关闭前先让 /readyz 失败的时间，留给负载均衡摘除实例
+	ShutdownDelaySeconds int `yaml:"shutdown_delay_seconds" env:"SHUTDOWN_DELAY_SECONDS" usage:"seconds /readyz reports not ready before connections are drained"`
+}
+
+// 数据库驱动
+const (
+	DatabaseDriverPostgres = "postgres"
+	DatabaseDriverSQLite   = "sqlite"
+)
+
+// DatabaseConfig 数据库连接配置，设置 URL 时忽略其余连接字段。
+// Driver 为 sqlite 时只使用 Path，用于本地开发和测试
+type DatabaseConfig struct {
+	Driver   string `yaml:"driver" env:"DATABASE_DRIVER" usage:"database driver: postgres or sqlite"`
+	Path     string `yaml:"path" env:"DATABASE_PATH" usage:"SQLite database file, :memory: for an in-memory database"`
+	URL      string `yaml:"url" env:"DATABASE_URL" secret:"true" usage:"full database DSN, overrides the individual fields"`
+	Host     string `yaml:"host" env:"DATABASE_HOST,DB_HOST" usage:"database host"`
+	Port     int    `yaml:"port" env:"DATABASE_PORT,DB_PORT" usage:"database port"`
+	User     string `yaml:"user" env:"DATABASE_USERNAME,DB_USER" usage:"database user"`
+	Password string `yaml:"password" env:"DATABASE_PASSWORD,DB_PASSWORD" secret:"true" usage:"database password"`
+	Name     string `yaml:"name" env:"DATABASE_NAME,DB_NAME" usage:"database name"`
+	SSLMode  string `yaml:"ssl_mode" env:"DATABASE_SSLMODE" usage:"postgres sslmode"`
+	TimeZone string `yaml:"time_zone" env:"DATABASE_TIMEZONE" usage:"database session time zone"`
+
+	MaxOpenConns           int `yaml:"max_open_conns" env:"DATABASE_MAX_OPEN_CONNS" usage:"maximum open connections, 0 means unlimited"`
+	MaxIdleConns           int `yaml:"max_idle_conns" env:"DATABASE_MAX_IDLE_CONNS" usage:"maximum idle connections"`
+	ConnMaxLifetimeMinutes int `yaml:"conn_max_lifetime_minutes" env:"DATABASE_CONN_MAX_LIFETIME_MINUTES" usage:"minutes before a connection is recycled, 0 means forever"`
+	ConnMaxIdleTimeMinutes int `yaml:"conn_max_idle_time_minutes" env:"DATABASE_CONN_MAX_IDLE_TIME_MINUTES" usage:"minutes an idle connection is kept, 0 means forever"`
+	// ConnectRetries 启动时数据库未就绪的重试次数，间隔按指数退避
+	ConnectRetries int `yaml:"connect_retries" env:"DATABASE_CONNECT_RETRIES" usage:"connection attempts to retry at startup before giving up"`
+}
+
+// AuthConfig 认证配置
+type AuthConfig struct {
+	JWTSecret          string   `yaml:"jwt_secret" env:"JWT_SECRET" secret:"true" usage:"HMAC secret used to sign JWTs"`
+	JWTPreviousSecrets []string `yaml:"jwt_previous_secrets" env:"JWT_PREVIOUS_SECRETS" secret:"true" usage:"comma separated retired secrets still accepted when verifying JWTs"`
+	JWTExpiresHours    int      `yaml:"jwt_expires_hours" env:"JWT_EXPIRES_IN" usage:"JWT lifetime in hours"`
+	CacheTTLSeconds    int      `yaml:"cache_ttl_seconds" env:"AUTH_CACHE_TTL" usage:"authenticated user cache TTL in seconds"`
+	GitHubClientID     string   `yaml:"github_client_id" env:"GITHUB_CLIENT_ID" usage:"GitHub OAuth client id"`
+	GitHubClientSecret string   `yaml:"github_client_secret" env:"GITHUB_CLIENT_SECRET" secret:"true" usage:"GitHub OAuth client secret"`
+	GitHubCallbackURL  string   `yaml:"github_callback_url" env:"GITHUB_CALLBACK_URL" usage:"GitHub OAuth callback URL"`
+}
+
+// AccountConfig 账户生命周期配置
+type AccountConfig struct {
+	DeletionGraceDays  int `yaml:"deletion_grace_days" env:"ACCOUNT_DELETION_GRACE_DAYS" usage:"days a deleted account can be restored"`
+	RewardCoolingHours int `yaml:"reward_cooling_hours" env:"REWARD_ADDRESS_COOLING_HOURS" usage:"hours before a reward address change takes effect"`
+}
+
+// CORSConfig 跨域配置，AllowedOrigins 为空时不允许任何跨域请求
+type CORSConfig struct {
+	AllowedOrigins   []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" usage:"comma separated list of allowed origins, empty disables cross-origin requests"`
+	AllowedMethods   []string `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS" usage:"comma separated list of methods allowed on cross-origin requests"`
+	AllowedHeaders   []string `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS" usage:"comma separated list of request headers allowed on cross-origin requests"`
+	ExposedHeaders   []string `yaml:"exposed_headers" env:"CORS_EXPOSED_HEADERS" usage:"comma separated list of response headers readable by cross-origin scripts"`
+	AllowCredentials bool     `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" usage:"allow cookies on cross-origin requests"`
+	MaxAgeSeconds    int      `yaml:"max_age_seconds" env:"CORS_MAX_AGE" usage:"seconds browsers may cache a preflight response"`
+}
+
+// SecurityConfig 安全响应头和请求体限制
+type SecurityConfig struct {
+	// HSTSMaxAgeSeconds 为0时不发送 Strict-Transport-Security
+	HSTSMaxAgeSeconds     int    `yaml:"hsts_max_age_seconds" env:"HSTS_MAX_AGE" usage:"Strict-Transport-Security max-age in seconds, 0 disables the header"`
+	HSTSIncludeSubdomains bool   `yaml:"hsts_include_subdomains" env:"HSTS_INCLUDE_SUBDOMAINS" usage:"add includeSubDomains to Strict-Transport-Security"`
+	ReferrerPolicy        string `yaml:"referrer_policy" env:"REFERRER_POLICY" usage:"Referrer-Policy header value"`
+	BodyLimitBytes        int    `yaml:"body_limit_bytes" env:"BODY_LIMIT_BYTES" usage:"maximum request body size in bytes"`
+}
+
+// ChainConfig 链上RPC配置
+type ChainConfig struct {
+	RPCURL  string `yaml:"rpc_url" env:"CHAIN_RPC_URL" secret:"true" usage:"EVM JSON-RPC endpoint"`
+	ChainID int    `yaml:"chain_id" env:"CHAIN_ID" usage:"EVM chain id"`
+}
+
+// KubernetesConfig MCP服务部署所用的Kubernetes配置
+type KubernetesConfig struct {
+	APIHost     string `yaml:"api_host" env:"K8S_API_HOST" usage:"Kubernetes API server URL"`
+	BearerToken string `yaml:"bearer_token" env:"K8S_BEARER_TOKEN" secret:"true" usage:"Kubernetes bearer token"`
+	Namespace   string `yaml:"namespace" env:"K8S_NAMESPACE" usage:"namespace for MCP server workloads"`
+}
 
+// MailConfig 邮件发送配置，未设置 Host 时不发送邮件
+type MailConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST" usage:"SMTP host"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" usage:"SMTP port"`
+	Username string `yaml:"username" env:"SMTP_USERNAME" usage:"SMTP username"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true" usage:"SMTP password"`
+	From     string `yaml:"from" env:"MAIL_FROM" usage:"sender address"`
+}
+
+// LogConfig 日志配置
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL" usage:"log level: debug, info, warn or error"`
+	// AccessSampleRatio 成功请求写入访问日志的比例，失败和慢请求始终记录
+	AccessSampleRatio float64 `yaml:"access_sample_ratio" env:"ACCESS_LOG_SAMPLE_RATIO" usage:"fraction of successful requests written to the access log, from 0 to 1"`
+	SlowRequestMs     int     `yaml:"slow_request_ms" env:"SLOW_REQUEST_MS" usage:"requests slower than this many milliseconds are always logged"`
+}
+
+// MetricsConfig Prometheus指标配置
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" env:"METRICS_ENABLED" usage:"expose Prometheus metrics at /metrics"`
+	Token   string `yaml:"token" env:"METRICS_TOKEN" secret:"true" usage:"bearer token required to scrape /metrics"`
+}
+
+// 链路追踪导出方式
+const (
+	TraceExporterNone   = "none"
+	TraceExporterStdout = "stdout"
+	TraceExporterFile   = "file"
+	TraceExporterOTLP   = "otlp"
+)
+
+// TracingConfig OpenTelemetry链路追踪配置，Exporter 为 none 时不导出
+type TracingConfig struct {
+	Exporter    string   `yaml:"exporter" env:"TRACING_EXPORTER" usage:"span exporter: none, stdout, file or otlp"`
+	Endpoint    string   `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" usage:"OTLP/HTTP endpoint, defaults to http://localhost:4318"`
+	Headers     []string `yaml:"headers" env:"OTEL_EXPORTER_OTLP_HEADERS" secret:"true" usage:"comma separated key=value headers sent to the OTLP endpoint"`
+	File        string   `yaml:"file" env:"TRACING_FILE" usage:"file spans are appended to when exporter is file"`
+	SampleRatio float64  `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" usage:"fraction of new traces to sample, from 0 to 1"`
+	ServiceName string   `yaml:"service_name" env:"OTEL_SERVICE_NAME" usage:"service.name reported with every span"`
+}
+
+// Default 返回开发环境下的默认配置
+func Default() *Config {
 	return &Config{
-		ServerPort:   getEnv("PORT", "8443"),
-		Env:          getEnv("ENV", "development"),
-		DBHost:       getEnv("DB_HOST", "localhost"),
-		DBPort:       getEnv("DB_PORT", "5432"),
-		DBUser:       getEnv("DB_USER", ""),
-		DBPassword:   getEnv("DB_PASSWORD", ""),
-		DBName:       getEnv("DB_NAME", ""),
-		JWTSecret:    getEnv("JWT_SECRET", "your-secret-key"),
-		JWTExpiresIn: getEnvInt("JWT_EXPIRES_IN", 24),
-		LogLevel:     getEnv("LOG_LEVEL", "info"),
+		Server: ServerConfig{
+			Port:                   "8443",
+			Env:                    EnvDevelopment,
+			ShutdownTimeoutSeconds: 30,
+		},
+		Database: DatabaseConfig{
+			Driver:   DatabaseDriverPostgres,
+			Path:     "mcpforge.db",
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Name:     "mcpforge",
+			SSLMode:  "disable",
+			TimeZone: "Asia/Shanghai",
+
+			MaxOpenConns:           25,
+			MaxIdleConns:           10,
+			ConnMaxLifetimeMinutes: 30,
+			ConnMaxIdleTimeMinutes: 5,
+			ConnectRetries:         10,
+		},
+		Auth: AuthConfig{
+			JWTSecret:       insecureJWTSecret,
+			JWTExpiresHours: 24,
+			CacheTTLSeconds: 30,
+		},
+		Account: AccountConfig{
+			DeletionGraceDays:  30,
+			RewardCoolingHours: 48,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:3000"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			// 允许浏览器端传递W3C trace上下文和请求ID
+			AllowedHeaders:   []string{"Content-Type", "Authorization", "traceparent", "tracestate", "X-Request-ID"},
+			ExposedHeaders:   []string{"X-Request-ID"},
+			AllowCredentials: true,
+			MaxAgeSeconds:    86400,
+		},
+		Security: SecurityConfig{
+			ReferrerPolicy: "no-referrer",
+			BodyLimitBytes: 1 << 20,
+		},
+		Kubernetes: KubernetesConfig{
+			Namespace: "default",
+		},
+		Mail: MailConfig{
+			Port: 587,
+		},
+		Log: LogConfig{
+			Level:             "info",
+			AccessSampleRatio: 1,
+			SlowRequestMs:     1000,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TraceExporterNone,
+			SampleRatio: 1,
+			ServiceName: "mcpforge",
+		},
 	}
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// DefaultFor 返回指定环境的默认配置。预发和生产环境不预设跨域来源并开启HSTS，
+// 其余环境与 Default 相同
+func DefaultFor(env string) *Config {
+	cfg := Default()
+	cfg.Server.Env = env
+	switch env {
+	case EnvStaging, EnvProduction:
+		cfg.CORS.AllowedOrigins = nil
+		cfg.Security.HSTSMaxAgeSeconds = 365 * 24 * 60 * 60
+		cfg.Security.HSTSIncludeSubdomains = true
 	}
-	return defaultValue
+	return cfg
+}
+
+// IsProduction 是否为生产环境
+func (c *Config) IsProduction() bool {
+	return c.Server.Env == EnvProduction
 }
 
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
+// DSN 构建所选驱动的连接字符串
+func (d DatabaseConfig) DSN() string {
+	if d.URL != "" {
+		return d.URL
+	}
+	if d.Driver == DatabaseDriverSQLite {
+		return sqliteDSN(d.Path)
+	}
+
+	pairs := []struct{ key, value string }{
+		{"host", d.Host},
+		{"port", fmt.Sprint(d.Port)},
+		{"user", d.User},
+		{"password", d.Password},
+		{"dbname", d.Name},
+		{"sslmode", d.SSLMode},
+		{"TimeZone", d.TimeZone},
+	}
+	parts := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		if p.value == "" {
+			continue
 		}
+		parts = append(parts, p.key+"="+quoteDSNValue(p.value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// sqliteDSN 开启外键约束，并在锁冲突时等待而不是立即返回 database is locked
+func sqliteDSN(path string) string {
+	params := "_foreign_keys=1&_busy_timeout=5000"
+	if path == ":memory:" {
+		return "file::memory:?" + params
 	}
-	return defaultValue
-}
\ No newline at end of file
+	return "file:" + path + "?" + params
+}
+
+// quoteDSNValue 对包含空格或引号的值加引号
+func quoteDSNValue(v string) string {
+	if !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+	return "'" + v + "'"
+}
+
+// isAbsoluteURL 判断是否为带协议和主机的URL
+func isAbsoluteURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
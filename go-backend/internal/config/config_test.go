@@ -0,0 +1,341 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// isolateEnv 清空所有配置相关的环境变量并切换到空目录，避免本机的 .env 和环境影响结果。
+// 空值的环境变量在加载时被忽略
+func isolateEnv(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	t.Setenv(ConfigFileEnv, "")
+	for _, f := range Default().fields() {
+		for _, key := range f.env {
+			t.Setenv(key, "")
+		}
+	}
+}
+
+// writeFile 在临时目录写入文件并返回路径
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	isolateEnv(t)
+	path := writeFile(t, "config.yaml", `
+server:
+  port: "9000"
+database:
+  driver: sqlite
+  path: from-yaml.db
+log:
+  level: warn
+  slow_request_ms: 250
+`)
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("DATABASE_PATH", "from-env.db")
+
+	cfg, rest, err := Load([]string{"--config", path, "--database.path", "from-flag.db", "migrate", "up"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{"default", cfg.Auth.JWTExpiresHours, 24},
+		{"yaml over default", cfg.Server.Port, "9000"},
+		{"yaml only", cfg.Log.SlowRequestMs, 250},
+		{"env over yaml", cfg.Log.Level, "error"},
+		{"flag over env", cfg.Database.Path, "from-flag.db"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if !slices.Equal(rest, []string{"migrate", "up"}) {
+		t.Errorf("remaining args = %q", rest)
+	}
+}
+
+func TestLoadConfigFileFromEnv(t *testing.T) {
+	isolateEnv(t)
+	t.Setenv(ConfigFileEnv, writeFile(t, "config.yaml", "server:\n  port: \"9001\"\n"))
+
+	cfg, _, err := Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Port != "9001" {
+		t.Errorf("port = %s, want the value from %s", cfg.Server.Port, ConfigFileEnv)
+	}
+
+	// --config 优先于环境变量
+	flagPath := writeFile(t, "other.yaml", "server:\n  port: \"9002\"\n")
+	if cfg, _, err = Load([]string{"--config", flagPath}); err != nil || cfg.Server.Port != "9002" {
+		t.Errorf("port = %v (%v), want the value from --config", cfg, err)
+	}
+}
+
+func TestLoadEnvironmentDefaults(t *testing.T) {
+	isolateEnv(t)
+	t.Setenv("ENV", EnvStaging)
+	t.Setenv("METRICS_TOKEN", strings.Repeat("m", minMetricsTokenLength))
+
+	cfg, _, err := Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.CORS.AllowedOrigins) != 0 || cfg.Security.HSTSMaxAgeSeconds == 0 {
+		t.Errorf("staging loaded development defaults: origins %v, hsts %d", cfg.CORS.AllowedOrigins, cfg.Security.HSTSMaxAgeSeconds)
+	}
+
+	// 环境在配置文件中指定时同样以该环境的默认值为基础，文件中的其他值仍然生效
+	isolateEnv(t)
+	path := writeFile(t, "config.yaml", `
+server:
+  env: staging
+metrics:
+  token: staging-metrics-token
+cors:
+  allowed_origins: ["https://app.example.com"]
+`)
+	cfg, _, err = Load([]string{"--config", path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Env != EnvStaging || cfg.Security.HSTSMaxAgeSeconds == 0 {
+		t.Errorf("env %s, hsts %d", cfg.Server.Env, cfg.Security.HSTSMaxAgeSeconds)
+	}
+	if !slices.Equal(cfg.CORS.AllowedOrigins, []string{"https://app.example.com"}) {
+		t.Errorf("origins = %v", cfg.CORS.AllowedOrigins)
+	}
+}
+
+func TestDefaultFor(t *testing.T) {
+	for _, env := range []string{EnvDevelopment, EnvTest} {
+		cfg := DefaultFor(env)
+		if cfg.Server.Env != env || !slices.Equal(cfg.CORS.AllowedOrigins, Default().CORS.AllowedOrigins) || cfg.Security.HSTSMaxAgeSeconds != 0 {
+			t.Errorf("DefaultFor(%s) = %+v", env, cfg)
+		}
+	}
+	for _, env := range []string{EnvStaging, EnvProduction} {
+		cfg := DefaultFor(env)
+		if cfg.Server.Env != env || cfg.CORS.AllowedOrigins != nil || cfg.Security.HSTSMaxAgeSeconds == 0 || !cfg.Security.HSTSIncludeSubdomains {
+			t.Errorf("DefaultFor(%s) = %+v", env, cfg)
+		}
+	}
+	// 返回的是新副本
+	DefaultFor(EnvTest).CORS.AllowedOrigins[0] = "changed"
+	if Default().CORS.AllowedOrigins[0] == "changed" {
+		t.Error("DefaultFor shares state with Default")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) []string
+		wantErr string
+	}{
+		{"unknown yaml field", func(t *testing.T) []string {
+			return []string{"--config", writeFile(t, "c.yaml", "server:\n  prot: \"1\"\n")}
+		}, "field prot not found"},
+		{"missing config file", func(t *testing.T) []string {
+			return []string{"--config", filepath.Join(t.TempDir(), "missing.yaml")}
+		}, "read config file"},
+		{"bad env value", func(t *testing.T) []string {
+			t.Setenv("JWT_EXPIRES_IN", "soon")
+			return nil
+		}, "env JWT_EXPIRES_IN"},
+		{"bad flag value", func(t *testing.T) []string {
+			return []string{"--metrics.enabled=maybe"}
+		}, "flag --metrics.enabled"},
+		{"unknown flag", func(t *testing.T) []string {
+			return []string{"--no-such-flag"}
+		}, "no-such-flag"},
+		{"invalid result", func(t *testing.T) []string {
+			return []string{"--log.level", "verbose"}
+		}, `log.level: unknown level "verbose"`},
+		{"malformed .env", func(t *testing.T) []string {
+			if err := os.WriteFile(".env", []byte("JWT_SECRET='unterminated\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			return nil
+		}, "read .env file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateEnv(t)
+			args := tt.setup(t)
+			if _, _, err := Load(args); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadDotEnv(t *testing.T) {
+	isolateEnv(t)
+	// .env 不覆盖已存在的环境变量，因此先移除；t.Setenv 负责在结束时恢复
+	os.Unsetenv("LOG_LEVEL")
+	if err := os.WriteFile(".env", []byte("LOG_LEVEL=warn\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, _, err := Load(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Log.Level != "warn" {
+		t.Errorf("log level = %s, want the value from .env", cfg.Log.Level)
+	}
+}
+
+// productionConfig 满足全部生产环境要求的配置
+func productionConfig() *Config {
+	cfg := DefaultFor(EnvProduction)
+	cfg.Auth.JWTSecret = strings.Repeat("s", minProductionSecretLength)
+	cfg.Database.Password = "db-password"
+	cfg.Database.SSLMode = "require"
+	cfg.Metrics.Token = strings.Repeat("m", minMetricsTokenLength)
+	cfg.Mail.Host = "smtp.example.com"
+	cfg.Mail.From = "MCPForge <noreply@example.com>"
+	return cfg
+}
+
+func TestValidateProduction(t *testing.T) {
+	if err := productionConfig().Validate(); err != nil {
+		t.Fatalf("hardened production config rejected: %v", err)
+	}
+
+	err := DefaultFor(EnvProduction).Validate()
+	if err == nil {
+		t.Fatal("production accepted the development defaults")
+	}
+	for _, want := range []string{
+		"auth.jwt_secret: the development default must not be used",
+		"database.password: is required",
+		"database.ssl_mode: must not be disable",
+		"metrics.token: must be at least",
+		"mail.host: is required to notify users of account changes",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%v", want, err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{"short secret", func(c *Config) { c.Auth.JWTSecret = "short" }, "auth.jwt_secret: must be at least 32 characters"},
+		{"default previous secret", func(c *Config) {
+			c.Auth.JWTPreviousSecrets = []string{strings.Repeat("p", 40), insecureJWTSecret}
+		}, "auth.jwt_previous_secrets[1]: the development default must not be used"},
+		{"short previous secret", func(c *Config) { c.Auth.JWTPreviousSecrets = []string{"short"} }, "auth.jwt_previous_secrets[0]: must be at least 32 characters"},
+		{"sqlite", func(c *Config) { c.Database.Driver = DatabaseDriverSQLite }, "database.driver: must be postgres"},
+		{"wildcard origin", func(c *Config) { c.CORS.AllowedOrigins = []string{"*"}; c.CORS.AllowCredentials = false }, "cors.allowed_origins: wildcard origin is not allowed"},
+		{"no hsts", func(c *Config) { c.Security.HSTSMaxAgeSeconds = 0 }, "security.hsts_max_age_seconds: must be positive"},
+		{"debug logging", func(c *Config) { c.Log.Level = "debug" }, "log.level: debug logging is not allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := productionConfig()
+			tt.modify(cfg)
+			if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// 同样的配置在开发环境可以使用
+	if err := Default().Validate(); err != nil {
+		t.Errorf("development defaults rejected: %v", err)
+	}
+}
+
+func TestValidateMetricsToken(t *testing.T) {
+	token := strings.Repeat("m", minMetricsTokenLength)
+	tests := []struct {
+		env     string
+		enabled bool
+		token   string
+		wantErr bool
+	}{
+		{EnvDevelopment, true, "", false},
+		{EnvTest, true, "", false},
+		{EnvStaging, true, "", true},
+		{EnvStaging, true, "short", true},
+		{EnvStaging, true, token, false},
+		{EnvStaging, false, "", false},
+	}
+	for _, tt := range tests {
+		cfg := DefaultFor(tt.env)
+		cfg.Metrics.Enabled, cfg.Metrics.Token = tt.enabled, tt.token
+		err := cfg.Validate()
+		if tt.wantErr != (err != nil && strings.Contains(err.Error(), "metrics.token")) {
+			t.Errorf("%s enabled=%v token=%q: err = %v", tt.env, tt.enabled, tt.token, err)
+		}
+	}
+}
+
+func TestWriteYAMLRedactsSecrets(t *testing.T) {
+	cfg := Default()
+	secrets := map[string]*string{
+		"jwt":     &cfg.Auth.JWTSecret,
+		"db":      &cfg.Database.Password,
+		"url":     &cfg.Database.URL,
+		"github":  &cfg.Auth.GitHubClientSecret,
+		"rpc":     &cfg.Chain.RPCURL,
+		"k8s":     &cfg.Kubernetes.BearerToken,
+		"smtp":    &cfg.Mail.Password,
+		"metrics": &cfg.Metrics.Token,
+	}
+	for name, p := range secrets {
+		*p = "secret-" + name
+	}
+	cfg.Auth.JWTPreviousSecrets = []string{"secret-old-1", "secret-old-2"}
+	cfg.Tracing.Headers = []string{"authorization=secret-otlp"}
+
+	var out bytes.Buffer
+	if err := cfg.WriteYAML(&out); err != nil {
+		t.Fatal(err)
+	}
+	printed := out.String()
+	if strings.Contains(printed, "secret-") {
+		t.Errorf("printed config leaks a secret:\n%s", printed)
+	}
+	if n := strings.Count(printed, redactedValue); n != len(secrets)+3 {
+		t.Errorf("%d redacted values, want %d:\n%s", n, len(secrets)+3, printed)
+	}
+	// 非敏感字段原样输出
+	if !strings.Contains(printed, "http://localhost:3000") {
+		t.Errorf("printed config is missing the CORS origin:\n%s", printed)
+	}
+
+	// 原配置不受影响
+	if cfg.Auth.JWTSecret != "secret-jwt" || cfg.Auth.JWTPreviousSecrets[0] != "secret-old-1" {
+		t.Error("Redacted modified the original config")
+	}
+
+	// 未设置的敏感字段保持为空，便于看出缺少配置
+	if redacted := Default().Redacted(); redacted.Database.Password != "" || redacted.Metrics.Token != "" {
+		t.Errorf("empty secrets printed as %q and %q", redacted.Database.Password, redacted.Metrics.Token)
+	}
+}
@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv 指定配置文件路径的环境变量，可被 --config 参数覆盖
+const ConfigFileEnv = "MCPFORGE_CONFIG"
+
+// field 配置树中的一个叶子字段
+type field struct {
+	path   string // yaml路径，如 database.host
+	env    []string
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+// Load 加载并校验配置，args 为命令行参数（不含程序名），返回解析参数后剩余的位置参数。
+// 默认值取决于运行环境，因此先确定 server.env，再以该环境的默认值为基础重新加载
+func Load(args []string) (*Config, []string, error) {
+	fields := Default().fields()
+
+	fs := flag.NewFlagSet("mcpforge", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configPath := fs.String("config", "", "path to a YAML config file (env "+ConfigFileEnv+")")
+	flagValues := make(map[string]*flagValue, len(fields))
+	for _, f := range fields {
+		fv := &flagValue{isBool: f.value.Kind() == reflect.Bool}
+		flagValues[f.flagName()] = fv
+		fs.Var(fv, f.flagName(), f.usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(os.Stderr)
+			fs.PrintDefaults()
+		}
+		return nil, nil, err
+	}
+
+	// 日志在配置加载后才初始化，.env 存在但无法解析时直接返回错误
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("read .env file: %w", err)
+	}
+
+	path := *configPath
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+
+	cfg := Default()
+	if err := cfg.apply(path, fs, flagValues); err != nil {
+		return nil, nil, err
+	}
+	if env := cfg.Server.Env; env != EnvDevelopment {
+		cfg = DefaultFor(env)
+		if err := cfg.apply(path, fs, flagValues); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// apply 依次用配置文件、环境变量和已设置的命令行参数覆盖当前值
+func (c *Config) apply(path string, fs *flag.FlagSet, flagValues map[string]*flagValue) error {
+	if path != "" {
+		if err := c.loadFile(path); err != nil {
+			return err
+		}
+	}
+
+	fields := c.fields()
+	for _, f := range fields {
+		for _, key := range f.env {
+			if raw, ok := os.LookupEnv(key); ok && raw != "" {
+				if err := setValue(f.value, raw); err != nil {
+					return fmt.Errorf("env %s: %w", key, err)
+				}
+				break
+			}
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(fl *flag.Flag) {
+		fv, ok := flagValues[fl.Name]
+		if !ok || flagErr != nil {
+			return
+		}
+		for _, f := range fields {
+			if f.flagName() == fl.Name {
+				if err := setValue(f.value, fv.raw); err != nil {
+					flagErr = fmt.Errorf("flag --%s: %w", fl.Name, err)
+				}
+			}
+		}
+	})
+	return flagErr
+}
+
+// loadFile 从YAML文件覆盖配置，未知字段视为错误以避免拼写错误被静默忽略
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// fields 按声明顺序列出所有叶子字段
+func (c *Config) fields() []field {
+	var out []field
+	collectFields(reflect.ValueOf(c).Elem(), "", &out)
+	return out
+}
+
+func collectFields(v reflect.Value, prefix string, out *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct {
+			collectFields(fv, path, out)
+			continue
+		}
+
+		f := field{
+			path:   path,
+			usage:  sf.Tag.Get("usage"),
+			secret: sf.Tag.Get("secret") == "true",
+			value:  fv,
+		}
+		if env := sf.Tag.Get("env"); env != "" {
+			f.env = strings.Split(env, ",")
+		}
+		*out = append(*out, f)
+	}
+}
+
+// flagName 命令行参数名，下划线替换为连字符
+func (f field) flagName() string {
+	return strings.ReplaceAll(f.path, "_", "-")
+}
+
+// setValue 将字符串解析为字段类型并赋值，切片按逗号分隔
+func setValue(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
+
+// flagValue 记录命令行原始值，在环境变量之后再应用
+type flagValue struct {
+	raw    string
+	isBool bool
+}
+
+func (f *flagValue) String() string     { return f.raw }
+func (f *flagValue) Set(s string) error { f.raw = s; return nil }
+func (f *flagValue) IsBoolFlag() bool   { return f.isBool }
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue 打印时替代敏感字段的占位符
+const redactedValue = "<redacted>"
+
+// Redacted 返回隐藏了敏感字段的配置副本
+func (c *Config) Redacted() *Config {
+	clone := *c
+	clone.CORS.AllowedOrigins = append([]string(nil), c.CORS.AllowedOrigins...)
+	for _, f := range clone.fields() {
+		if !f.secret {
+			continue
+		}
+		switch f.value.Kind() {
+		case reflect.String:
+			if f.value.String() != "" {
+				f.value.SetString(redactedValue)
+			}
+		case reflect.Slice:
+			redacted := make([]string, f.value.Len())
+			for i := range redacted {
+				redacted[i] = redactedValue
+			}
+			f.value.Set(reflect.ValueOf(redacted))
+		}
+	}
+	return &clone
+}
+
+// WriteYAML 以YAML格式输出隐藏敏感字段后的配置
+func (c *Config) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
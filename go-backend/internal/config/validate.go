@@ -0,0 +1,260 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// minProductionSecretLength 生产环境JWT密钥的最小长度
+const minProductionSecretLength = 32
+
+// minMetricsTokenLength 开发和测试以外的环境中指标接口token的最小长度
+const minMetricsTokenLength = 16
+
+// corsMethods 允许跨域使用的请求方法
+var corsMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// referrerPolicies Referrer-Policy 的合法取值
+var referrerPolicies = []string{
+	"no-referrer", "no-referrer-when-downgrade", "origin", "origin-when-cross-origin",
+	"same-origin", "strict-origin", "strict-origin-when-cross-origin", "unsafe-url",
+}
+
+// Validate 校验配置，返回汇总了所有问题的错误
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(path, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	if !slices.Contains([]string{EnvDevelopment, EnvTest, EnvStaging, EnvProduction}, c.Server.Env) {
+		fail("server.env", "unknown environment %q", c.Server.Env)
+	}
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		fail("server.port", "must be a port number, got %q", c.Server.Port)
+	}
+	if c.Server.ShutdownTimeoutSeconds <= 0 {
+		fail("server.shutdown_timeout_seconds", "must be positive")
+	}
+	if c.Server.ShutdownDelaySeconds < 0 {
+		fail("server.shutdown_delay_seconds", "must not be negative")
+	}
+
+	switch c.Database.Driver {
+	case DatabaseDriverPostgres:
+	case DatabaseDriverSQLite:
+		if c.Database.URL == "" && c.Database.Path == "" {
+			fail("database.path", "is required for sqlite")
+		}
+	default:
+		fail("database.driver", "unknown driver %q", c.Database.Driver)
+	}
+	if c.Database.Driver == DatabaseDriverPostgres && c.Database.URL == "" {
+		if c.Database.Host == "" {
+			fail("database.host", "is required")
+		}
+		if c.Database.Port < 1 || c.Database.Port > 65535 {
+			fail("database.port", "must be a port number, got %d", c.Database.Port)
+		}
+		if c.Database.User == "" {
+			fail("database.user", "is required")
+		}
+		if c.Database.Name == "" {
+			fail("database.name", "is required")
+		}
+	}
+
+	if c.Database.MaxOpenConns < 0 {
+		fail("database.max_open_conns", "must not be negative")
+	}
+	if c.Database.MaxIdleConns < 0 {
+		fail("database.max_idle_conns", "must not be negative")
+	} else if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("database.max_idle_conns", "must not exceed max_open_conns")
+	}
+	if c.Database.ConnMaxLifetimeMinutes < 0 {
+		fail("database.conn_max_lifetime_minutes", "must not be negative")
+	}
+	if c.Database.ConnMaxIdleTimeMinutes < 0 {
+		fail("database.conn_max_idle_time_minutes", "must not be negative")
+	}
+	if c.Database.ConnectRetries < 0 {
+		fail("database.connect_retries", "must not be negative")
+	}
+
+	if c.Auth.JWTSecret == "" {
+		fail("auth.jwt_secret", "is required")
+	}
+	if c.Auth.JWTExpiresHours <= 0 {
+		fail("auth.jwt_expires_hours", "must be positive")
+	}
+	if c.Auth.CacheTTLSeconds < 0 {
+		fail("auth.cache_ttl_seconds", "must not be negative")
+	}
+	if c.Auth.GitHubCallbackURL != "" && !isAbsoluteURL(c.Auth.GitHubCallbackURL) {
+		fail("auth.github_callback_url", "must be an absolute URL")
+	}
+
+	if c.Account.DeletionGraceDays <= 0 {
+		fail("account.deletion_grace_days", "must be positive")
+	}
+	if c.Account.RewardCoolingHours < 0 {
+		fail("account.reward_cooling_hours", "must not be negative")
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				fail("cors.allowed_origins", "wildcard origin cannot be combined with allow_credentials")
+			}
+			continue
+		}
+		if !isAbsoluteURL(origin) {
+			fail("cors.allowed_origins", "invalid origin %q", origin)
+		}
+	}
+
+	for _, method := range c.CORS.AllowedMethods {
+		if !slices.Contains(corsMethods, strings.ToUpper(method)) {
+			fail("cors.allowed_methods", "unknown method %q", method)
+		}
+	}
+	for _, header := range c.CORS.AllowedHeaders {
+		if header == "*" && c.CORS.AllowCredentials {
+			fail("cors.allowed_headers", "wildcard header cannot be combined with allow_credentials")
+		}
+	}
+	if c.CORS.MaxAgeSeconds < 0 {
+		fail("cors.max_age_seconds", "must not be negative")
+	}
+
+	if c.Security.HSTSMaxAgeSeconds < 0 {
+		fail("security.hsts_max_age_seconds", "must not be negative")
+	}
+	if !slices.Contains(referrerPolicies, c.Security.ReferrerPolicy) {
+		fail("security.referrer_policy", "unknown policy %q", c.Security.ReferrerPolicy)
+	}
+	if c.Security.BodyLimitBytes <= 0 {
+		fail("security.body_limit_bytes", "must be positive")
+	}
+
+	if c.Chain.RPCURL != "" && !isAbsoluteURL(c.Chain.RPCURL) {
+		fail("chain.rpc_url", "must be an absolute URL")
+	}
+	if c.Chain.ChainID < 0 {
+		fail("chain.chain_id", "must not be negative")
+	}
+	if c.Kubernetes.APIHost != "" && !isAbsoluteURL(c.Kubernetes.APIHost) {
+		fail("kubernetes.api_host", "must be an absolute URL")
+	}
+	if c.Mail.Host != "" {
+		if c.Mail.Port < 1 || c.Mail.Port > 65535 {
+			fail("mail.port", "must be a port number, got %d", c.Mail.Port)
+		}
+		if c.Mail.From == "" {
+			fail("mail.from", "is required when mail.host is set")
+		}
+	}
+
+	if !slices.Contains([]string{"debug", "info", "warn", "error"}, c.Log.Level) {
+		fail("log.level", "unknown level %q", c.Log.Level)
+	}
+	if c.Log.AccessSampleRatio < 0 || c.Log.AccessSampleRatio > 1 {
+		fail("log.access_sample_ratio", "must be between 0 and 1, got %g", c.Log.AccessSampleRatio)
+	}
+	if c.Log.SlowRequestMs < 0 {
+		fail("log.slow_request_ms", "must not be negative")
+	}
+
+	switch c.Tracing.Exporter {
+	case TraceExporterNone, TraceExporterStdout:
+	case TraceExporterFile:
+		if c.Tracing.File == "" {
+			fail("tracing.file", "is required when tracing.exporter is file")
+		}
+	case TraceExporterOTLP:
+		if c.Tracing.Endpoint != "" && !isAbsoluteURL(c.Tracing.Endpoint) {
+			fail("tracing.endpoint", "must be an absolute URL")
+		}
+		for _, header := range c.Tracing.Headers {
+			if key, _, ok := strings.Cut(header, "="); !ok || strings.TrimSpace(key) == "" {
+				fail("tracing.headers", "expected key=value, got %q", header)
+			}
+		}
+	default:
+		fail("tracing.exporter", "unknown exporter %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sample_ratio", "must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	}
+	if c.Tracing.Exporter != TraceExporterNone && c.Tracing.ServiceName == "" {
+		fail("tracing.service_name", "is required when tracing is enabled")
+	}
+
+	// 空token时指标接口不做认证，只允许在本地开发和测试时使用
+	localEnv := c.Server.Env == EnvDevelopment || c.Server.Env == EnvTest
+	if c.Metrics.Enabled && !localEnv && len(c.Metrics.Token) < minMetricsTokenLength {
+		fail("metrics.token", "must be at least %d characters when metrics are enabled in %s", minMetricsTokenLength, c.Server.Env)
+	}
+
+	if c.IsProduction() {
+		errs = append(errs, c.validateProduction()...)
+	}
+	return errors.Join(errs...)
+}
+
+// CheckJWTSecret 检查JWT密钥能否在生产环境使用：不能是开发默认值，且长度足够
+func CheckJWTSecret(secret string) error {
+	if secret == insecureJWTSecret {
+		return errors.New("the development default must not be used")
+	}
+	if len(secret) < minProductionSecretLength {
+		return fmt.Errorf("must be at least %d characters", minProductionSecretLength)
+	}
+	return nil
+}
+
+// validateProduction 生产环境拒绝使用不安全的默认值
+func (c *Config) validateProduction() []error {
+	var errs []error
+	fail := func(path, msg string) {
+		errs = append(errs, fmt.Errorf("%s: %s in production", path, msg))
+	}
+
+	if err := CheckJWTSecret(c.Auth.JWTSecret); err != nil {
+		fail("auth.jwt_secret", err.Error())
+	}
+	// 旧密钥同样用于验证token，弱密钥会让任何人都能伪造token
+	for i, secret := range c.Auth.JWTPreviousSecrets {
+		if err := CheckJWTSecret(secret); err != nil {
+			fail(fmt.Sprintf("auth.jwt_previous_secrets[%d]", i), err.Error())
+		}
+	}
+	if c.Database.Driver != DatabaseDriverPostgres {
+		fail("database.driver", "must be postgres")
+	} else if c.Database.URL == "" {
+		if c.Database.Password == "" {
+			fail("database.password", "is required")
+		}
+		if c.Database.SSLMode == "disable" {
+			fail("database.ssl_mode", "must not be disable")
+		}
+	}
+	if slices.Contains(c.CORS.AllowedOrigins, "*") {
+		fail("cors.allowed_origins", "wildcard origin is not allowed")
+	}
+	if c.Security.HSTSMaxAgeSeconds == 0 {
+		fail("security.hsts_max_age_seconds", "must be positive")
+	}
+	// 收款地址变更的冷静期依赖邮件通知账户所有者
+	if c.Mail.Host == "" {
+		fail("mail.host", "is required to notify users of account changes")
+	}
+	if c.Log.Level == "debug" {
+		fail("log.level", "debug logging is not allowed")
+	}
+	return errs
+}
@@ -0,0 +1,62 @@
+// Package database 按配置打开Postgres或SQLite连接
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+)
+
+// Open 打开数据库连接并设置连接池
+func Open(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case config.DatabaseDriverPostgres:
+		dialector = postgres.Open(cfg.DSN())
+	case config.DatabaseDriverSQLite:
+		dialector = sqlite.Dialector{DriverName: sqliteDriverName, DSN: cfg.DSN()}
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		// 将唯一约束等数据库错误转换为gorm错误
+		TranslateError: true,
+	})
+	if err != nil {
+		// 连接检查失败时gorm仍会返回已创建的连接池
+		if db != nil {
+			Close(db)
+		}
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Driver == config.DatabaseDriverSQLite {
+		// SQLite同一时间只允许一个写入者，且内存数据库随连接关闭而消失，
+		// 因此固定使用一个永不回收的连接
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		return db, nil
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetimeMinutes) * time.Minute)
+	sqlDB.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTimeMinutes) * time.Minute)
+	return db, nil
+}
+
+// Close 关闭底层连接池
+func Close(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
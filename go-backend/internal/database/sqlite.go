@@ -0,0 +1,49 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName 参数中的时间统一转为UTC的SQLite驱动
+const sqliteDriverName = "sqlite3_utc"
+
+func init() {
+	sql.Register(sqliteDriverName, &utcDriver{})
+}
+
+// utcDriver SQLite以文本保存时间并按字符串比较，不同时区的时间无法正确比较大小。
+// 写入前统一转为UTC，使 deleted_at < ? 等条件与Postgres的结果一致
+type utcDriver struct {
+	sqlite3.SQLiteDriver
+}
+
+// Open 打开连接并包装为 utcConn
+func (d *utcDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &utcConn{SQLiteConn: conn.(*sqlite3.SQLiteConn)}, nil
+}
+
+// utcConn 转换查询参数中的时间，其余行为与 SQLiteConn 相同
+type utcConn struct {
+	*sqlite3.SQLiteConn
+}
+
+// CheckNamedValue 实现 driver.NamedValueChecker，先按默认规则转换（含 gorm.DeletedAt 等 Valuer）再转换时区
+func (c *utcConn) CheckNamedValue(nv *driver.NamedValue) error {
+	v, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	if t, ok := v.(time.Time); ok {
+		v = t.UTC()
+	}
+	nv.Value = v
+	return nil
+}
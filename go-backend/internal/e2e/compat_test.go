@@ -0,0 +1,180 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apitest"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+)
+
+// newCompatServer 启用Node.js兼容路由的测试服务
+func newCompatServer(t *testing.T) *apitest.Server {
+	return apitest.New(t, func(cfg *config.Config) { cfg.Server.NodeCompat = true })
+}
+
+// decodeCompat 兼容路由直接返回data，不带信封
+func decodeCompat(t *testing.T, resp *apitest.Response, wantStatus int, out any) {
+	t.Helper()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("status = %d, want %d: %s", resp.StatusCode, wantStatus, resp.Body)
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		t.Fatalf("decode: %v\n%s", err, resp.Body)
+	}
+}
+
+// compatError Node.js版本的错误格式
+type compatError struct {
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+	Error      string `json:"error"`
+}
+
+func TestCompatAuthRoutes(t *testing.T) {
+	srv := newCompatServer(t)
+
+	var status models.AuthStatusResponse
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/auth/status", nil, ""), http.StatusOK, &status)
+	if !status.Success || status.Timestamp.IsZero() {
+		t.Errorf("status = %+v", status)
+	}
+
+	var unauthorized compatError
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/auth/me", nil, ""), http.StatusUnauthorized, &unauthorized)
+	if unauthorized.StatusCode != http.StatusUnauthorized {
+		t.Errorf("error = %+v", unauthorized)
+	}
+
+	alice := srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("alice")})
+	var me models.SessionResponse
+	decodeCompat(t, alice.Do(t, http.MethodGet, "/auth/me", nil), http.StatusOK, &me)
+	want := models.SessionUser{UserID: alice.User.UserID, Username: "alice", Role: models.UserRoleUser}
+	if !me.Success || me.User != want {
+		t.Errorf("me = %+v, want user %+v", me, want)
+	}
+
+	var issued models.BearerTokenResponse
+	decodeCompat(t, alice.Do(t, http.MethodGet, "/auth/bearer-token", nil), http.StatusOK, &issued)
+	if issued.ExpiresIn != "7d" || issued.BearerToken == "" {
+		t.Fatalf("bearer token = %+v", issued)
+	}
+	claims, err := utils.NewJWTUtil(srv.Config).VerifyToken(issued.BearerToken)
+	if err != nil || claims.UserID != alice.User.UserID {
+		t.Fatalf("issued token claims = %+v, %v", claims, err)
+	}
+
+	// 签发的令牌可以直接访问接口
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/auth/me", nil, issued.BearerToken), http.StatusOK, &me)
+	if me.User.UserID != alice.User.UserID {
+		t.Errorf("bearer token resolved to user %d", me.User.UserID)
+	}
+}
+
+func TestCompatMCPRoutes(t *testing.T) {
+	srv := newCompatServer(t)
+	ownerID := uint(42)
+	srv.Store.AddMCPServer(models.MCPServer{Name: "old", Image: "example/old:1"})
+	srv.Store.AddMCPServer(models.MCPServer{
+		Name:    "weather",
+		Image:   "example/weather:1",
+		Status:  json.RawMessage(`{"phase":"Running","url":"http://weather"}`),
+		OwnerID: &ownerID,
+	})
+	card := srv.Store.AddMCPCard(models.MCPCard{Name: ptr("Weather"), GithubURL: "https://github.com/example/weather", Tags: []string{"weather"}})
+
+	var list models.MCPServerResourceList
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/mcpserver", nil, ""), http.StatusOK, &list)
+	if list.Kind != "MCPServerList" || len(list.Items) != 2 || list.Items[0].Metadata.Name != "weather" {
+		t.Fatalf("list = %+v", list)
+	}
+
+	var server struct {
+		Metadata models.MCPServerResourceMetadata `json:"metadata"`
+		Spec     models.MCPServerResourceSpec     `json:"spec"`
+		Status   struct {
+			Phase string `json:"phase"`
+			URL   string `json:"url"`
+		} `json:"status"`
+	}
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/mcpserver/weather", nil, ""), http.StatusOK, &server)
+	if server.Spec.Image != "example/weather:1" || server.Status.Phase != "Running" || server.Metadata.Labels["user"] != "42" {
+		t.Errorf("server = %+v", server)
+	}
+
+	var notFound compatError
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/mcpserver/missing", nil, ""), http.StatusNotFound, &notFound)
+
+	var cards []models.MCPCard
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/mcpcard", nil, ""), http.StatusOK, &cards)
+	if len(cards) != 1 || cards[0].ID != card.ID {
+		t.Fatalf("cards = %+v", cards)
+	}
+	var got models.MCPCard
+	decodeCompat(t, srv.Do(t, http.MethodGet, fmt.Sprintf("/mcpcard/%d", card.ID), nil, ""), http.StatusOK, &got)
+	if got.GithubURL != card.GithubURL {
+		t.Errorf("card = %+v", got)
+	}
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/mcpcard/999", nil, ""), http.StatusNotFound, &notFound)
+	decodeCompat(t, srv.Do(t, http.MethodGet, "/mcpcard/abc", nil, ""), http.StatusBadRequest, &notFound)
+}
+
+func TestCompatNotImplemented(t *testing.T) {
+	srv := newCompatServer(t)
+	alice := srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{})
+
+	tests := []struct {
+		method, path string
+		token        string
+	}{
+		{http.MethodPost, "/user", alice.Token},
+		{http.MethodPost, fmt.Sprintf("/user/%d/bind-auth", alice.User.UserID), alice.Token},
+		{http.MethodGet, "/user/auth/github", ""},
+		{http.MethodGet, "/user/auth/github/callback", ""},
+		{http.MethodPost, "/user/auth/github/callback", ""},
+		{http.MethodPost, "/mcpserver", ""},
+		{http.MethodDelete, "/mcpserver/weather", ""},
+		{http.MethodPost, "/mcpcard", ""},
+		{http.MethodPost, "/mcpcard/import", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			var body compatError
+			decodeCompat(t, srv.Do(t, tt.method, tt.path, map[string]string{}, tt.token), http.StatusNotImplemented, &body)
+			if body.StatusCode != http.StatusNotImplemented || body.Error != "Not Implemented" || body.Message == "" {
+				t.Errorf("body = %+v", body)
+			}
+		})
+	}
+}
+
+func TestCompatUserByAuth(t *testing.T) {
+	srv := newCompatServer(t)
+	alice := srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("alice")})
+	bob := srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("bob")})
+	admin, _ := srv.LoginAs(t, "admin", models.UserRoleAdmin)
+
+	path := func(s *apitest.Session) string {
+		return "/user/by-auth?auth_type=web3&auth_identifier=" + s.User.AuthMethods[0].AuthIdentifier
+	}
+
+	var user models.User
+	decodeCompat(t, alice.Do(t, http.MethodGet, path(alice), nil), http.StatusOK, &user)
+	if user.UserID != alice.User.UserID {
+		t.Errorf("own lookup returned user %d", user.UserID)
+	}
+
+	// 查询他人的钱包与查询不存在的钱包结果相同
+	var notFound compatError
+	decodeCompat(t, alice.Do(t, http.MethodGet, path(bob), nil), http.StatusNotFound, &notFound)
+	decodeCompat(t, alice.Do(t, http.MethodGet, "/user/by-auth?auth_type=web3&auth_identifier=0xmissing", nil), http.StatusNotFound, &notFound)
+
+	decodeCompat(t, admin.Do(t, http.MethodGet, path(bob), nil), http.StatusOK, &user)
+	if user.UserID != bob.User.UserID {
+		t.Errorf("admin lookup returned user %d", user.UserID)
+	}
+}
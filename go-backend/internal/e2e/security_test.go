@@ -0,0 +1,205 @@
+package e2e_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apitest"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+)
+
+const verifyPath = "/api/v1/user/auth/web3/verify"
+
+// send 发送带自定义请求头的请求
+func send(t *testing.T, srv *apitest.Server, method, path string, header map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	srv := apitest.New(t)
+	resp := srv.Do(t, http.MethodGet, "/api/v1/status", nil, "")
+	for header, want := range map[string]string{
+		fiber.HeaderXContentTypeOptions:   "nosniff",
+		fiber.HeaderXFrameOptions:         "DENY",
+		fiber.HeaderReferrerPolicy:        "no-referrer",
+		fiber.HeaderContentSecurityPolicy: middleware.APIContentSecurityPolicy,
+	} {
+		if got := resp.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if got := resp.Header.Get(fiber.HeaderStrictTransportSecurity); got != "" {
+		t.Errorf("HSTS sent in test environment: %q", got)
+	}
+
+	// 错误响应同样带安全响应头
+	resp = srv.Do(t, http.MethodGet, "/api/v1/user/1", nil, "")
+	if resp.Header.Get(fiber.HeaderXContentTypeOptions) != "nosniff" {
+		t.Errorf("error response missing security headers: %v", resp.Header)
+	}
+
+	srv = apitest.New(t, func(cfg *config.Config) {
+		prod := config.DefaultFor(config.EnvProduction)
+		cfg.Security = prod.Security
+	})
+	resp = srv.Do(t, http.MethodGet, "/api/v1/status", nil, "")
+	if got := resp.Header.Get(fiber.HeaderStrictTransportSecurity); got != "max-age=31536000; includeSubDomains" {
+		t.Errorf("HSTS = %q", got)
+	}
+}
+
+func TestDocsContentSecurityPolicy(t *testing.T) {
+	srv := apitest.New(t)
+	resp := srv.Do(t, http.MethodGet, "/api/v1/docs", nil, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET docs: status %d", resp.StatusCode)
+	}
+
+	csp := resp.Header.Get(fiber.HeaderContentSecurityPolicy)
+	if csp == middleware.APIContentSecurityPolicy || !strings.Contains(csp, "frame-ancestors 'none'") {
+		t.Fatalf("docs CSP = %q", csp)
+	}
+
+	// 每个内联脚本都必须按哈希放行，否则浏览器会拒绝执行
+	inline := regexp.MustCompile(`(?s)<script>(.*?)</script>`).FindAllSubmatch(resp.Body, -1)
+	if len(inline) == 0 {
+		t.Fatal("docs page has no inline script")
+	}
+	for _, m := range inline {
+		sum := sha256.Sum256(m[1])
+		if hash := "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"; !strings.Contains(csp, hash) {
+			t.Errorf("CSP %q does not allow inline script %s", csp, hash)
+		}
+	}
+}
+
+func TestCORS(t *testing.T) {
+	preflight := func(srv *apitest.Server, origin string) *http.Response {
+		return send(t, srv, http.MethodOptions, verifyPath, map[string]string{
+			fiber.HeaderOrigin:                      origin,
+			fiber.HeaderAccessControlRequestMethod:  http.MethodPost,
+			fiber.HeaderAccessControlRequestHeaders: "content-type,authorization",
+		})
+	}
+
+	t.Run("allowed origin", func(t *testing.T) {
+		srv := apitest.New(t)
+		resp := preflight(srv, "http://localhost:3000")
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("preflight status %d", resp.StatusCode)
+		}
+		for header, want := range map[string]string{
+			fiber.HeaderAccessControlAllowOrigin:      "http://localhost:3000",
+			fiber.HeaderAccessControlAllowCredentials: "true",
+			fiber.HeaderAccessControlAllowMethods:     "GET, POST, PUT, DELETE, OPTIONS",
+			fiber.HeaderAccessControlMaxAge:           "86400",
+		} {
+			if got := resp.Header.Get(header); got != want {
+				t.Errorf("%s = %q, want %q", header, got, want)
+			}
+		}
+
+		resp = send(t, srv, http.MethodGet, "/api/v1/status", map[string]string{fiber.HeaderOrigin: "http://localhost:3000"})
+		if got := resp.Header.Get(fiber.HeaderAccessControlExposeHeaders); got != fiber.HeaderXRequestID {
+			t.Errorf("expose headers = %q", got)
+		}
+	})
+
+	t.Run("other origin", func(t *testing.T) {
+		resp := preflight(apitest.New(t), "https://evil.example.com")
+		if got := resp.Header.Get(fiber.HeaderAccessControlAllowOrigin); got != "" {
+			t.Errorf("allow origin = %q for an unlisted origin", got)
+		}
+	})
+
+	t.Run("configured methods", func(t *testing.T) {
+		srv := apitest.New(t, func(cfg *config.Config) {
+			cfg.CORS.AllowedOrigins = []string{"https://app.example.com"}
+			cfg.CORS.AllowedMethods = []string{"GET"}
+		})
+		resp := preflight(srv, "https://app.example.com")
+		if got := resp.Header.Get(fiber.HeaderAccessControlAllowMethods); got != "GET" {
+			t.Errorf("allow methods = %q, want GET", got)
+		}
+	})
+
+	t.Run("production defaults", func(t *testing.T) {
+		srv := apitest.New(t, func(cfg *config.Config) {
+			cfg.CORS = config.DefaultFor(config.EnvProduction).CORS
+		})
+		resp := preflight(srv, "http://localhost:3000")
+		if got := resp.Header.Get(fiber.HeaderAccessControlAllowOrigin); got != "" {
+			t.Errorf("allow origin = %q without configured origins", got)
+		}
+	})
+}
+
+func TestStrictJSON(t *testing.T) {
+	srv := apitest.New(t)
+	wallet := apitest.NewWallet(t)
+	body := `{"address":"` + wallet.Address + `","nonce":"n","signature":"s"`
+
+	for name, raw := range map[string]string{
+		"unknown field": body + `,"admin":true}`,
+		"trailing data": body + `}{}`,
+		"trailing text": body + `} x`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv.Do(t, http.MethodPost, verifyPath, raw, "").ExpectError(t, apperrors.ErrInvalidBody)
+		})
+	}
+
+	// 结尾的空白不算多余内容，继续按正常流程校验nonce
+	srv.Do(t, http.MethodPost, verifyPath, body+"}\n", "").ExpectError(t, apperrors.ErrInvalidNonce)
+}
+
+func TestBodyLimit(t *testing.T) {
+	srv := apitest.New(t, func(cfg *config.Config) {
+		cfg.Security.BodyLimitBytes = 1024
+	})
+
+	// 请求体限制由 fiber 的服务端在读取时执行，需直接监听而不是经过 net/http 适配器
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.App.Listener(ln, fiber.ListenConfig{DisableStartupMessage: true}) }()
+	t.Cleanup(func() { _ = srv.App.Shutdown() })
+
+	body := `{"nonce":"` + strings.Repeat("a", 2048) + `"}`
+	resp, err := http.Post("http://"+ln.Addr().String()+verifyPath, fiber.MIMEApplicationJSON, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	(&apitest.Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}).
+		ExpectError(t, apperrors.New(http.StatusRequestEntityTooLarge, apperrors.CodePayloadTooLarge, ""))
+}
@@ -0,0 +1,228 @@
+package e2e_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apitest"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+)
+
+func userPath(id uint) string {
+	return fmt.Sprintf("/api/v1/user/%d", id)
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	srv := apitest.New(t)
+	wallet := apitest.NewWallet(t)
+
+	username, email := "alice", "alice@example.com"
+	resp := srv.Authenticate(t, wallet, models.Web3AuthRequest{Username: &username, Email: &email})
+	var registered models.Web3AuthResponse
+	resp.Decode(t, &registered)
+	if registered.Action != "register" || registered.User.Username != username || registered.User.Role != models.UserRoleUser {
+		t.Fatalf("unexpected register response %+v", registered)
+	}
+	if registered.User.Email == nil || *registered.User.Email != email {
+		t.Errorf("email = %v, want %s", registered.User.Email, email)
+	}
+	if len(registered.User.AuthMethods) != 1 || registered.User.AuthMethods[0].AuthType != models.AuthTypeWeb3 {
+		t.Errorf("auth methods = %+v", registered.User.AuthMethods)
+	}
+
+	cookie := resp.Cookie(middleware.AuthCookieName)
+	if cookie == nil || cookie.Value == "" || !cookie.HttpOnly || cookie.Path != "/" {
+		t.Fatalf("auth cookie = %+v", cookie)
+	}
+
+	// 再次登录返回同一用户，注册参数被忽略
+	other := "alice2"
+	session := srv.Login(t, wallet, models.Web3AuthRequest{Username: &other})
+	if session.Action != "login" || session.User.UserID != registered.User.UserID || session.User.Username != username {
+		t.Errorf("unexpected login %+v", session)
+	}
+
+	var me models.User
+	session.Do(t, http.MethodGet, userPath(session.User.UserID), nil).Decode(t, &me)
+	if me.UserID != registered.User.UserID {
+		t.Errorf("GET own account returned user %d", me.UserID)
+	}
+
+	// 其他钱包不能注册已占用的用户名
+	resp = srv.Authenticate(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: &username})
+	resp.ExpectError(t, apperrors.ErrUsernameTaken)
+}
+
+func TestLoginRejectsBadChallenges(t *testing.T) {
+	srv := apitest.New(t)
+	wallet := apitest.NewWallet(t)
+	verify := func(req models.Web3AuthRequest) *apitest.Response {
+		return srv.Do(t, http.MethodPost, "/api/v1/user/auth/web3/verify", req, "")
+	}
+
+	t.Run("signed by another wallet", func(t *testing.T) {
+		nonce := srv.Challenge(t, wallet.Address)
+		signature := apitest.NewWallet(t).Sign(t, nonce)
+		verify(models.Web3AuthRequest{Address: wallet.Address, Nonce: nonce, Signature: signature}).
+			ExpectError(t, apperrors.ErrInvalidSignature)
+	})
+
+	t.Run("nonce is single use", func(t *testing.T) {
+		nonce := srv.Challenge(t, wallet.Address)
+		req := models.Web3AuthRequest{Address: wallet.Address, Nonce: nonce, Signature: wallet.Sign(t, nonce)}
+		verify(req).Decode(t, nil)
+		verify(req).ExpectError(t, apperrors.ErrInvalidNonce)
+	})
+
+	t.Run("nonce issued for another address", func(t *testing.T) {
+		nonce := srv.Challenge(t, apitest.NewWallet(t).Address)
+		verify(models.Web3AuthRequest{Address: wallet.Address, Nonce: nonce, Signature: wallet.Sign(t, nonce)}).
+			ExpectError(t, apperrors.ErrInvalidNonce)
+	})
+
+	t.Run("superseded nonce", func(t *testing.T) {
+		first := srv.Challenge(t, wallet.Address)
+		srv.Challenge(t, wallet.Address)
+		verify(models.Web3AuthRequest{Address: wallet.Address, Nonce: first, Signature: wallet.Sign(t, first)}).
+			ExpectError(t, apperrors.ErrInvalidNonce)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		details := verify(models.Web3AuthRequest{Address: "not-an-address"}).ExpectError(t, apperrors.ErrValidation)
+		if len(details) == 0 {
+			t.Error("expected field errors")
+		}
+		srv.Do(t, http.MethodGet, "/api/v1/user/auth/web3/challenge?address=0x123", nil, "").
+			ExpectError(t, apperrors.ErrValidation)
+		srv.Do(t, http.MethodPost, "/api/v1/user/auth/web3/verify", "{", "").
+			ExpectError(t, apperrors.ErrInvalidBody)
+	})
+}
+
+func TestUpdateUser(t *testing.T) {
+	srv := apitest.New(t)
+	bob := srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("bob")})
+	srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("carol")})
+
+	var updated models.User
+	bob.Do(t, http.MethodPut, userPath(bob.User.UserID), models.UpdateUserRequest{
+		Username:    ptr("bobby"),
+		Email:       ptr("bob@example.com"),
+		DisplayName: ptr("Bob"),
+		Links:       []models.ProfileLink{{Label: "site", URL: "https://bob.example.com"}},
+	}).Decode(t, &updated)
+	if updated.Username != "bobby" || updated.Email == nil || *updated.Email != "bob@example.com" || len(updated.Links) != 1 {
+		t.Errorf("unexpected update result %+v", updated)
+	}
+
+	var me models.User
+	bob.Do(t, http.MethodGet, userPath(bob.User.UserID), nil).Decode(t, &me)
+	if me.Username != "bobby" || me.DisplayName == nil || *me.DisplayName != "Bob" {
+		t.Errorf("update not persisted: %+v", me)
+	}
+
+	path := userPath(bob.User.UserID)
+	bob.Do(t, http.MethodPut, path, models.UpdateUserRequest{Username: ptr("carol")}).ExpectError(t, apperrors.ErrUsernameTaken)
+	bob.Do(t, http.MethodPut, path, models.UpdateUserRequest{Role: ptr(models.UserRoleAdmin)}).ExpectError(t, apperrors.ErrRoleReadOnly)
+	bob.Do(t, http.MethodPut, path, models.UpdateUserRequest{RewardAddress: ptr(apitest.NewWallet(t).Address)}).ExpectError(t, apperrors.ErrRewardAddressReadOnly)
+
+	details := bob.Do(t, http.MethodPut, path, models.UpdateUserRequest{Email: ptr("not-an-email")}).ExpectError(t, apperrors.ErrValidation)
+	if len(details) != 1 || details[0].Field != "email" {
+		t.Errorf("field errors = %+v, want one for email", details)
+	}
+}
+
+func TestDeleteAndRestoreAccount(t *testing.T) {
+	srv := apitest.New(t)
+	wallet := apitest.NewWallet(t)
+	dave := srv.Login(t, wallet, models.Web3AuthRequest{Username: ptr("dave")})
+
+	var deleted models.DeleteUserResponse
+	dave.Do(t, http.MethodDelete, userPath(dave.User.UserID), nil).Decode(t, &deleted)
+	if deleted.RestoreUntil == 0 {
+		t.Errorf("restore_until not set: %+v", deleted)
+	}
+
+	// 删除立即生效，已签发的token不再可用
+	dave.Do(t, http.MethodGet, userPath(dave.User.UserID), nil).ExpectError(t, apperrors.ErrUserGone)
+
+	// 宽限期内需要显式恢复，用户名仍被占用
+	srv.Authenticate(t, wallet, models.Web3AuthRequest{}).ExpectError(t, apperrors.ErrAccountPendingDeletion)
+	srv.Authenticate(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("dave")}).ExpectError(t, apperrors.ErrUsernameTaken)
+
+	restored := srv.Login(t, wallet, models.Web3AuthRequest{Restore: true})
+	if restored.Action != "restore" || restored.User.UserID != dave.User.UserID {
+		t.Fatalf("unexpected restore %+v", restored)
+	}
+	restored.Do(t, http.MethodGet, userPath(dave.User.UserID), nil).Decode(t, nil)
+}
+
+func TestAuthorizationFailures(t *testing.T) {
+	srv := apitest.New(t)
+	erin := srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("erin")})
+	frank := srv.Login(t, apitest.NewWallet(t), models.Web3AuthRequest{Username: ptr("frank")})
+	admin, _ := srv.LoginAs(t, "root", models.UserRoleAdmin)
+
+	// 用其他密钥签发的token
+	otherCfg := config.Default()
+	otherCfg.Auth.JWTSecret = "another-secret-that-is-not-the-servers"
+	forged, err := utils.NewJWTUtil(otherCfg).GenerateToken(erin.User.UserID, "erin", string(models.UserRoleAdmin))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frankPath := userPath(frank.User.UserID)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   any
+		token  string
+		want   *apperrors.Error
+	}{
+		{"no token", http.MethodGet, frankPath, nil, "", apperrors.ErrAuthRequired},
+		{"malformed token", http.MethodGet, frankPath, nil, "not-a-jwt", apperrors.ErrInvalidToken},
+		{"foreign signing key", http.MethodGet, frankPath, nil, forged, apperrors.ErrInvalidToken},
+		{"read another account", http.MethodGet, frankPath, nil, erin.Token, apperrors.ErrNotAccountOwner},
+		{"update another account", http.MethodPut, frankPath, models.UpdateUserRequest{DisplayName: ptr("x")}, erin.Token, apperrors.ErrNotAccountOwner},
+		{"delete another account", http.MethodDelete, frankPath, nil, erin.Token, apperrors.ErrNotAccountOwner},
+		{"list users as non-admin", http.MethodGet, "/api/v1/user", nil, erin.Token, apperrors.ErrForbidden},
+		{"admin route", http.MethodGet, "/api/v1/admin/role-applications", nil, erin.Token, apperrors.ErrForbidden},
+		{"logout without token", http.MethodPost, "/api/v1/user/auth/logout", nil, "", apperrors.ErrAuthRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.Do(t, tt.method, tt.path, tt.body, tt.token).ExpectError(t, tt.want)
+		})
+	}
+
+	// 管理员可以列出和管理其他账户
+	var users []models.User
+	admin.Do(t, http.MethodGet, "/api/v1/user", nil).Decode(t, &users)
+	if len(users) != 3 {
+		t.Errorf("admin listed %d users, want 3", len(users))
+	}
+	var user models.User
+	admin.Do(t, http.MethodGet, frankPath, nil).Decode(t, &user)
+	if user.UserID != frank.User.UserID {
+		t.Errorf("admin read user %d, want %d", user.UserID, frank.User.UserID)
+	}
+	admin.Do(t, http.MethodDelete, frankPath, nil).Decode(t, nil)
+	frank.Do(t, http.MethodGet, frankPath, nil).ExpectError(t, apperrors.ErrUserGone)
+
+	// 登出只清除cookie，客户端需丢弃token
+	resp := erin.Do(t, http.MethodPost, "/api/v1/user/auth/logout", nil)
+	resp.Decode(t, nil)
+	if c := resp.Cookie(middleware.AuthCookieName); c == nil || c.Value != "" {
+		t.Errorf("logout cookie = %+v, want cleared", c)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+)
+
+// bindJSON 解析请求体并按validate标签校验。
+// 校验失败返回字段错误，请求体无法解析时返回 ErrInvalidBody
+func bindJSON(c fiber.Ctx, out any) error {
+	return bindError(c.Bind().JSON(out))
+}
+
+// bindQuery 解析查询参数并按validate标签校验
+func bindQuery(c fiber.Ctx, out any) error {
+	return bindError(c.Bind().Query(out))
+}
+
+func bindError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperrors.ErrInvalidBody.Wrap(err)
+}
@@ -4,41 +4,83 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/buildinfo"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/health"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/reqctx"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
 )
 
+// requestLogger 返回附带请求ID、用户ID和trace信息的请求日志记录器，
+// 请求未经过访问日志中间件时退回l
+func requestLogger(c fiber.Ctx, l *logger.Logger) *logger.Logger {
+	return l.WithContext(reqctx.From(c))
+}
+
 type HealthHandler struct {
-	config *config.Config
-	logger *logger.Logger
+	config   *config.Config
+	logger   *logger.Logger
+	registry *health.Registry
 }
 
-func NewHealthHandler(cfg *config.Config, l *logger.Logger) *HealthHandler {
+func NewHealthHandler(cfg *config.Config, l *logger.Logger, registry *health.Registry) *HealthHandler {
 	return &HealthHandler{
-		config: cfg,
-		logger: l,
+		config:   cfg,
+		logger:   l,
+		registry: registry,
 	}
 }
 
+// HealthCheck 返回构建信息和运行时长，不检查依赖
 func (h *HealthHandler) HealthCheck(c fiber.Ctx) error {
-	h.logger.Info("Health check requested", "method", c.Method(), "path", c.Path())
-	
-	return utils.SuccessResponse(c, fiber.Map{
-		"status": "healthy",
-		"timestamp": time.Now().Unix(),
-		"version": "1.0.0",
-		"environment": h.config.Env,
-		"uptime": time.Now().Format(time.RFC3339),
+	status := "healthy"
+	if h.registry.ShuttingDown() {
+		status = "shutting_down"
+	}
+
+	return utils.SuccessResponse(c, models.ServiceStatus{
+		Status:        status,
+		Timestamp:     time.Now().Unix(),
+		Build:         buildinfo.Get(),
+		Environment:   h.config.Server.Env,
+		StartedAt:     h.registry.StartedAt().Unix(),
+		UptimeSeconds: int64(h.registry.Uptime().Seconds()),
 	})
 }
 
-func (h *HealthHandler) ReadyCheck(c fiber.Ctx) error {
-	h.logger.Info("Ready check requested", "method", c.Method(), "path", c.Path())
-	
-	return utils.SuccessResponse(c, fiber.Map{
-		"status": "ready",
+// Livez 存活检查，失败时应重启进程
+func (h *HealthHandler) Livez(c fiber.Ctx) error {
+	return h.probeResponse(c, h.registry.Liveness(reqctx.From(c)))
+}
+
+// Readyz 就绪检查，失败或关闭中时应停止转发流量
+func (h *HealthHandler) Readyz(c fiber.Ctx) error {
+	return h.probeResponse(c, h.registry.Readiness(reqctx.From(c)))
+}
+
+// probeResponse 检查通过返回200，否则返回503并附带各项检查结果。
+// 探针接口无需认证，检查的错误信息只写入日志
+func (h *HealthHandler) probeResponse(c fiber.Ctx, report health.Report) error {
+	l := requestLogger(c, h.logger)
+	for _, res := range report.Checks {
+		if res.Error != "" {
+			l.Warn("Health check failed", "check", res.Name, "critical", res.Critical, "error", res.Error)
+		}
+	}
+	if report.OK() {
+		return utils.SuccessResponse(c, report.Public())
+	}
+
+	l.Warn("Health probe failed", "path", c.Path(), "status", report.Status)
+	return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+		"success":   false,
+		"code":      apperrors.CodeServiceUnavailable,
+		"message":   "Service unavailable",
+		"data":      report.Public(),
 		"timestamp": time.Now().Unix(),
-		"database": "connected",
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+)
+
+// LogLevelHandler 运行时查看和调整日志级别，重启后恢复为配置中的级别
+type LogLevelHandler struct {
+	config *config.Config
+	logger *logger.Logger
+}
+
+// NewLogLevelHandler 创建日志级别处理器，l 需为 logger.New 创建的记录器
+func NewLogLevelHandler(cfg *config.Config, l *logger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		config: cfg,
+		logger: l,
+	}
+}
+
+// GetLogLevel 获取当前日志级别 GET /admin/log-level
+func (h *LogLevelHandler) GetLogLevel(c fiber.Ctx) error {
+	return utils.SuccessResponse(c, models.LogLevelResponse{Level: h.logger.Level()})
+}
+
+// SetLogLevel 调整日志级别 PUT /admin/log-level
+func (h *LogLevelHandler) SetLogLevel(c fiber.Ctx) error {
+	var req models.LogLevelRequest
+	if err := bindJSON(c, &req); err != nil {
+		return err
+	}
+
+	// 调试日志包含请求细节和个人数据，与配置校验一致，生产环境不允许开启
+	if req.Level == "debug" && h.config.IsProduction() {
+		return apperrors.Field("level", "debug logging is not allowed in production")
+	}
+
+	previous := h.logger.Level()
+	if err := h.logger.SetLevel(req.Level); err != nil {
+		return apperrors.Field("level", err.Error())
+	}
+
+	adminID, _ := middleware.GetUserID(c)
+	// 以Warn记录，调高级别后这条日志仍然可见
+	requestLogger(c, h.logger).Warn("Log level changed", "from", previous, "to", req.Level, "admin_id", adminID)
+	return utils.SuccessResponse(c, models.LogLevelResponse{Level: h.logger.Level()})
+}
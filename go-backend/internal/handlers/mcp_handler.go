@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+)
+
+// MCPHandler MCP服务器和卡片处理器
+type MCPHandler struct {
+	config     *config.Config
+	logger     *logger.Logger
+	mcpService *services.MCPService
+}
+
+// NewMCPHandler 创建MCP处理器
+func NewMCPHandler(cfg *config.Config, l *logger.Logger, mcpService *services.MCPService) *MCPHandler {
+	return &MCPHandler{
+		config:     cfg,
+		logger:     l,
+		mcpService: mcpService,
+	}
+}
+
+// ListServers 列出MCP服务器 GET /api/v1/mcp/servers
+func (h *MCPHandler) ListServers(c fiber.Ctx) error {
+	servers, err := h.mcpService.ListServers()
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, servers)
+}
+
+// GetServer 根据名称获取MCP服务器 GET /api/v1/mcp/servers/:name
+func (h *MCPHandler) GetServer(c fiber.Ctx) error {
+	server, err := h.mcpService.GetServer(c.Params("name"))
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, server)
+}
+
+// ListServerResources 以Kubernetes资源列表格式列出MCP服务器，兼容Node.js版本 GET /mcpserver
+func (h *MCPHandler) ListServerResources(c fiber.Ctx) error {
+	servers, err := h.mcpService.ListServers()
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, models.NewMCPServerResourceList(servers))
+}
+
+// GetServerResource 以Kubernetes资源格式获取MCP服务器，兼容Node.js版本 GET /mcpserver/:name
+func (h *MCPHandler) GetServerResource(c fiber.Ctx) error {
+	server, err := h.mcpService.GetServer(c.Params("name"))
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, models.NewMCPServerResource(server))
+}
+
+// ListCards 列出MCP卡片 GET /mcpcard、GET /api/v1/mcp/cards
+func (h *MCPHandler) ListCards(c fiber.Ctx) error {
+	cards, err := h.mcpService.ListCards()
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, cards)
+}
+
+// GetCard 根据ID获取MCP卡片 GET /mcpcard/:id、GET /api/v1/mcp/cards/:id
+func (h *MCPHandler) GetCard(c fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperrors.BadRequest("Invalid card ID")
+	}
+
+	card, err := h.mcpService.GetCard(uint(id))
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, card)
+}
+
+// NotImplemented 返回501，用于兼容层中Go版本尚未实现的接口
+func NotImplemented(feature string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		return apperrors.ErrNotImplemented.WithMessage("%s is not implemented by the Go backend", feature)
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+	"github.com/gofiber/fiber/v3"
+)
+
+// ProfileHandler 公开资料处理器
+type ProfileHandler struct {
+	config         *config.Config
+	logger         *logger.Logger
+	profileService *services.ProfileService
+}
+
+// NewProfileHandler 创建公开资料处理器
+func NewProfileHandler(cfg *config.Config, l *logger.Logger, profileService *services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{
+		config:         cfg,
+		logger:         l,
+		profileService: profileService,
+	}
+}
+
+// GetPublicProfile 获取开发者公开资料，无需登录 GET /profiles/:username
+func (h *ProfileHandler) GetPublicProfile(c fiber.Ctx) error {
+	username := c.Params("username")
+	if username == "" {
+		return apperrors.Field("username", "username is required")
+	}
+
+	profile, err := h.profileService.GetPublicProfile(username)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, profile)
+}
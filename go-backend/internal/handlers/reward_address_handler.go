@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+	"github.com/gofiber/fiber/v3"
+)
+
+// RewardAddressHandler 收款地址处理器
+type RewardAddressHandler struct {
+	config               *config.Config
+	logger               *logger.Logger
+	rewardAddressService *services.RewardAddressService
+}
+
+// NewRewardAddressHandler 创建收款地址处理器
+func NewRewardAddressHandler(cfg *config.Config, l *logger.Logger, rewardAddressService *services.RewardAddressService) *RewardAddressHandler {
+	return &RewardAddressHandler{
+		config:               cfg,
+		logger:               l,
+		rewardAddressService: rewardAddressService,
+	}
+}
+
+// GetRewardAddress 获取当前收款地址、待生效变更和历史 GET /user/me/reward-address
+func (h *RewardAddressHandler) GetRewardAddress(c fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	overview, err := h.rewardAddressService.Overview(userID)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, overview)
+}
+
+// GetChallenge 获取新收款地址的所有权挑战 POST /user/me/reward-address/challenge
+func (h *RewardAddressHandler) GetChallenge(c fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	var req models.RewardAddressChallengeRequest
+	if err := bindJSON(c, &req); err != nil {
+		requestLogger(c, h.logger).Warn("Invalid request body", "error", err.Error())
+		return err
+	}
+
+	response, err := h.rewardAddressService.GenerateChallenge(userID, req.Address)
+	if err != nil {
+		requestLogger(c, h.logger).Warn("Failed to generate reward address challenge", "error", err.Error(), "user_id", userID)
+		return err
+	}
+
+	return utils.SuccessResponse(c, response)
+}
+
+// RequestChange 提交签名后的收款地址变更 POST /user/me/reward-address
+func (h *RewardAddressHandler) RequestChange(c fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	var req models.RewardAddressChangeRequest
+	if err := bindJSON(c, &req); err != nil {
+		requestLogger(c, h.logger).Warn("Invalid request body", "error", err.Error())
+		return err
+	}
+
+	change, err := h.rewardAddressService.RequestChange(userID, &req)
+	if err != nil {
+		requestLogger(c, h.logger).Warn("Reward address change rejected", "error", err.Error(), "user_id", userID)
+		return err
+	}
+
+	requestLogger(c, h.logger).Info("Reward address change scheduled", "user_id", userID, "effective_at", change.EffectiveAt)
+	return utils.SuccessResponse(c, change)
+}
+
+// CancelPending 取消冷静期内的收款地址变更 DELETE /user/me/reward-address/pending
+func (h *RewardAddressHandler) CancelPending(c fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	change, err := h.rewardAddressService.CancelPending(userID)
+	if err != nil {
+		requestLogger(c, h.logger).Warn("Failed to cancel reward address change", "error", err.Error(), "user_id", userID)
+		return err
+	}
+
+	requestLogger(c, h.logger).Info("Reward address change cancelled", "user_id", userID, "change_id", change.ID)
+	return utils.SuccessResponse(c, change)
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+	"github.com/gofiber/fiber/v3"
+)
+
+// RoleApplicationHandler 开发者角色申请处理器
+type RoleApplicationHandler struct {
+	config             *config.Config
+	logger             *logger.Logger
+	applicationService *services.RoleApplicationService
+}
+
+// NewRoleApplicationHandler 创建角色申请处理器
+func NewRoleApplicationHandler(cfg *config.Config, l *logger.Logger, applicationService *services.RoleApplicationService) *RoleApplicationHandler {
+	return &RoleApplicationHandler{
+		config:             cfg,
+		logger:             l,
+		applicationService: applicationService,
+	}
+}
+
+// Submit 提交开发者申请 POST /user/me/developer-application
+func (h *RoleApplicationHandler) Submit(c fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	var req models.RoleApplicationRequest
+	if err := bindJSON(c, &req); err != nil {
+		requestLogger(c, h.logger).Warn("Invalid request body", "error", err.Error())
+		return err
+	}
+
+	application, err := h.applicationService.Submit(userID, &req)
+	if err != nil {
+		requestLogger(c, h.logger).Warn("Developer application rejected", "error", err.Error(), "user_id", userID)
+		return err
+	}
+
+	requestLogger(c, h.logger).Info("Developer application created", "user_id", userID, "application_id", application.ID)
+	return utils.SuccessResponse(c, application)
+}
+
+// ListMine 获取自己的申请历史 GET /user/me/developer-application
+func (h *RoleApplicationHandler) ListMine(c fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	applications, err := h.applicationService.ListMine(userID)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, applications)
+}
+
+// List 管理员按状态列出申请 GET /admin/role-applications?status=pending
+func (h *RoleApplicationHandler) List(c fiber.Ctx) error {
+	var status *models.RoleApplicationStatus
+	if value := c.Query("status"); value != "" {
+		s := models.RoleApplicationStatus(value)
+		switch s {
+		case models.RoleApplicationPending, models.RoleApplicationApproved, models.RoleApplicationRejected:
+			status = &s
+		default:
+			return apperrors.Field("status", "must be one of pending, approved, rejected")
+		}
+	}
+
+	applications, err := h.applicationService.List(status)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, applications)
+}
+
+// Get 管理员查看申请及审计事件 GET /admin/role-applications/:id
+func (h *RoleApplicationHandler) Get(c fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperrors.BadRequest("Invalid application ID")
+	}
+
+	application, err := h.applicationService.Get(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, application)
+}
+
+// Approve 通过申请 POST /admin/role-applications/:id/approve
+func (h *RoleApplicationHandler) Approve(c fiber.Ctx) error {
+	return h.review(c, h.applicationService.Approve)
+}
+
+// Reject 拒绝申请 POST /admin/role-applications/:id/reject
+func (h *RoleApplicationHandler) Reject(c fiber.Ctx) error {
+	return h.review(c, h.applicationService.Reject)
+}
+
+// review 审核申请的公共流程
+func (h *RoleApplicationHandler) review(c fiber.Ctx, decide func(id, reviewerID uint, notes *string) (*models.RoleApplication, error)) error {
+	reviewerID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return apperrors.BadRequest("Invalid application ID")
+	}
+
+	var req models.RoleApplicationReviewRequest
+	if len(c.Body()) > 0 {
+		if err := bindJSON(c, &req); err != nil {
+			requestLogger(c, h.logger).Warn("Invalid request body", "error", err.Error())
+			return err
+		}
+	}
+
+	application, err := decide(uint(id), reviewerID, req.Notes)
+	if err != nil {
+		requestLogger(c, h.logger).Warn("Role application review failed", "error", err.Error(), "application_id", id)
+		return err
+	}
+
+	requestLogger(c, h.logger).Info("Role application reviewed", "application_id", id, "status", application.Status, "reviewer_id", reviewerID)
+	return utils.SuccessResponse(c, application)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+)
+
+// bearerTokenTTL 与Node.js版本相同，Bearer令牌有效期为7天
+const (
+	bearerTokenTTL       = 7 * 24 * time.Hour
+	bearerTokenExpiresIn = "7d"
+)
+
+// GetSession 返回当前会话的用户 GET /auth/me
+func (h *Web3Handler) GetSession(c fiber.Ctx) error {
+	userID, _ := middleware.GetUserID(c)
+	username, _ := middleware.GetUsername(c)
+	role, _ := middleware.GetUserRole(c)
+
+	return utils.SuccessResponse(c, models.SessionResponse{
+		Success: true,
+		User: models.SessionUser{
+			UserID:   userID,
+			Username: username,
+			Role:     models.UserRole(role),
+		},
+		Message: "User authenticated successfully",
+	})
+}
+
+// IssueBearerToken 为当前用户签发Bearer令牌，供无法使用cookie的客户端使用 GET /auth/bearer-token
+func (h *Web3Handler) IssueBearerToken(c fiber.Ctx) error {
+	userID, _ := middleware.GetUserID(c)
+	username, _ := middleware.GetUsername(c)
+	role, _ := middleware.GetUserRole(c)
+
+	token, err := utils.NewJWTUtil(h.config).GenerateTokenWithTTL(userID, username, role, bearerTokenTTL)
+	if err != nil {
+		return apperrors.Internal(err).WithMessage("Failed to generate bearer token")
+	}
+
+	requestLogger(c, h.logger).Info("Bearer token issued", "user_id", userID)
+	return utils.SuccessResponse(c, models.BearerTokenResponse{
+		Success:     true,
+		BearerToken: token,
+		ExpiresIn:   bearerTokenExpiresIn,
+		Message:     "Bearer token generated successfully. Use this token in Authorization header: Bearer <token>",
+	})
+}
+
+// AuthStatus 认证服务状态，无需登录 GET /auth/status
+func (h *Web3Handler) AuthStatus(c fiber.Ctx) error {
+	return utils.SuccessResponse(c, models.AuthStatusResponse{
+		Success:   true,
+		Message:   "Auth service is running",
+		Timestamp: time.Now().UTC(),
+	})
+}
@@ -1,135 +1,269 @@
 package handlers
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/reqctx"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
 )
 
 // UserHandler 用户处理器
 type UserHandler struct {
-	config      *config.Config
-	logger      *logger.Logger
-	userService *services.UserService
+	config               *config.Config
+	logger               *logger.Logger
+	userService          *services.UserService
+	exportService        *services.ExportService
 }
 
 // NewUserHandler 创建用户处理器
-func NewUserHandler(cfg *config.Config, l *logger.Logger, userService *services.UserService) *UserHandler {
+func NewUserHandler(cfg *config.Config, l *logger.Logger, userService *services.UserService, exportService *services.ExportService) *UserHandler {
 	return &UserHandler{
-		config:      cfg,
-		logger:      l,
-		userService: userService,
+		config:               cfg,
+		logger:               l,
+		userService:          userService,
+		exportService:        exportService,
 	}
 }
 
 // CreateUser 创建用户 POST /user
 func (h *UserHandler) CreateUser(c fiber.Ctx) error {
-	h.logger.Info("Create user requested", "method", c.Method(), "path", c.Path())
-
 	var req models.CreateUserRequest
-	if err := c.Bind().JSON(&req); err != nil {
-		h.logger.Warn("Invalid request body", "error", err.Error())
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
-	}
-
-	// 基础验证
-	if req.Username == "" || req.AuthType == "" || req.AuthIdentifier == "" {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Username, auth_type, and auth_identifier are required")
+	if err := bindJSON(c, &req); err != nil {
+		requestLogger(c, h.logger).Warn("Invalid request body", "error", err.Error())
+		return err
 	}
 
-	user, err := h.userService.CreateUser(&req)
+	user, err := h.userService.CreateUser(reqctx.From(c), &req)
 	if err != nil {
-		h.logger.Error("Failed to create user", "error", err.Error(), "username", req.Username)
-		return utils.ErrorResponse(c, fiber.StatusConflict, err.Error())
+		requestLogger(c, h.logger).Warn("Failed to create user", "error", err.Error(), "username", req.Username)
+		return err
 	}
 
-	h.logger.Info("User created successfully", "user_id", user.UserID, "username", user.Username)
+	requestLogger(c, h.logger).Info("User created successfully", "user_id", user.UserID, "username", user.Username)
 	return utils.SuccessResponse(c, user)
 }
 
-// GetUsers 获取所有用户 GET /user
+// GetUsers 分页查询用户 GET /user，仅管理员可用
+// 支持 role, auth_type, auth_identifier, created_after, created_before,
+// username_prefix, email_prefix, sort, order, limit, offset, cursor 参数
 func (h *UserHandler) GetUsers(c fiber.Ctx) error {
-	h.logger.Info("Get all users requested", "method", c.Method(), "path", c.Path())
+	query, err := parseUserListQuery(c)
+	if err != nil {
+		return err
+	}
 
-	users, err := h.userService.GetAllUsers()
+	page, err := h.userService.ListUsers(reqctx.From(c), query)
 	if err != nil {
-		h.logger.Error("Failed to get users", "error", err.Error())
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to get users")
+		return err
 	}
 
-	h.logger.Info("Users retrieved successfully", "count", len(users))
-	return utils.SuccessResponse(c, users)
+	requestLogger(c, h.logger).Debug("Users retrieved successfully", "count", len(page.Users), "total", page.Pagination.Total)
+	return utils.PaginatedResponse(c, page.Users, page.Pagination)
+}
+
+// parseUserListQuery 解析用户列表查询参数
+func parseUserListQuery(c fiber.Ctx) (*models.UserListQuery, error) {
+	query := &models.UserListQuery{
+		SortBy: models.UserSortField(c.Query("sort", string(models.UserSortByID))),
+		Order:  models.SortOrder(c.Query("order", string(models.SortAsc))),
+		Cursor: c.Query("cursor"),
+	}
+
+	if !query.SortBy.IsValid() {
+		return nil, apperrors.Field("sort", "must be one of user_id, username, created_at, updated_at")
+	}
+	if query.Order != models.SortAsc && query.Order != models.SortDesc {
+		return nil, apperrors.Field("order", "must be asc or desc")
+	}
+
+	if v := c.Query("role"); v != "" {
+		role := models.UserRole(v)
+		if !role.IsValid() {
+			return nil, apperrors.Field("role", "invalid role")
+		}
+		query.Role = &role
+	}
+	if v := c.Query("auth_type"); v != "" {
+		authType := models.AuthType(v)
+		if !authType.IsValid() {
+			return nil, apperrors.Field("auth_type", "invalid auth_type")
+		}
+		query.AuthType = &authType
+	}
+	if v := c.Query("auth_identifier"); v != "" {
+		if query.AuthType == nil {
+			return nil, apperrors.Field("auth_identifier", "auth_identifier requires auth_type")
+		}
+		// web3地址统一以小写存储
+		if *query.AuthType == models.AuthTypeWeb3 {
+			v = strings.ToLower(v)
+		}
+		query.AuthIdentifier = &v
+	}
+	if v := c.Query("username_prefix"); v != "" {
+		query.UsernamePrefix = &v
+	}
+	if v := c.Query("email_prefix"); v != "" {
+		query.EmailPrefix = &v
+	}
+
+	for key, dst := range map[string]**time.Time{
+		"created_after":  &query.CreatedAfter,
+		"created_before": &query.CreatedBefore,
+	} {
+		if v := c.Query(key); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, apperrors.Field(key, "must be an RFC3339 timestamp")
+			}
+			*dst = &t
+		}
+	}
+
+	for key, dst := range map[string]*int{
+		"limit":  &query.Limit,
+		"offset": &query.Offset,
+	} {
+		if v := c.Query(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return nil, apperrors.Field(key, "must be a non-negative integer")
+			}
+			*dst = n
+		}
+	}
+
+	return query, nil
+}
+
+// GetUserByAuth 根据认证方法查找单个用户，只能查到本人的账户，管理员不受限制。
+// 兼容Node.js版本 GET /user/by-auth
+func (h *UserHandler) GetUserByAuth(c fiber.Ctx) error {
+	if c.Query("auth_type") == "" || c.Query("auth_identifier") == "" {
+		return apperrors.BadRequest("auth_type and auth_identifier are required")
+	}
+
+	query, err := parseUserListQuery(c)
+	if err != nil {
+		return err
+	}
+	query.Limit = 1
+
+	page, err := h.userService.ListUsers(reqctx.From(c), query)
+	if err != nil {
+		return err
+	}
+	// 他人的账户同样视为不存在，避免通过钱包地址查出账户
+	if len(page.Users) == 0 || !canAccessAccount(c, page.Users[0].UserID) {
+		return apperrors.ErrUserNotFound
+	}
+
+	return utils.SuccessResponse(c, page.Users[0])
+}
+
+// canAccessAccount 账户视图包含个人信息，只有本人和管理员可以访问
+func canAccessAccount(c fiber.Ctx, id uint) bool {
+	if userID, ok := middleware.GetUserID(c); ok && userID == id {
+		return true
+	}
+	role, _ := middleware.GetUserRole(c)
+	return role == string(models.UserRoleAdmin)
 }
 
 // GetUserByID 根据ID获取用户 GET /user/:id
 func (h *UserHandler) GetUserByID(c fiber.Ctx) error {
-	h.logger.Info("Get user by ID requested", "method", c.Method(), "path", c.Path())
-
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+		return apperrors.BadRequest("Invalid user ID")
+	}
+	if !canAccessAccount(c, uint(id)) {
+		return apperrors.ErrNotAccountOwner
 	}
 
-	user, err := h.userService.GetUserByID(uint(id))
+	user, err := h.userService.GetUserByID(reqctx.From(c), uint(id))
 	if err != nil {
-		h.logger.Error("Failed to get user", "error", err.Error(), "user_id", id)
-		return utils.ErrorResponse(c, fiber.StatusNotFound, err.Error())
+		return err
 	}
 
-	h.logger.Info("User retrieved successfully", "user_id", user.UserID)
+	requestLogger(c, h.logger).Debug("User retrieved successfully", "user_id", user.UserID)
 	return utils.SuccessResponse(c, user)
 }
 
 // UpdateUser 更新用户 PUT /user/:id
 func (h *UserHandler) UpdateUser(c fiber.Ctx) error {
-	h.logger.Info("Update user requested", "method", c.Method(), "path", c.Path())
-
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+		return apperrors.BadRequest("Invalid user ID")
+	}
+	if !canAccessAccount(c, uint(id)) {
+		return apperrors.ErrNotAccountOwner
 	}
 
 	var req models.UpdateUserRequest
-	if err := c.Bind().JSON(&req); err != nil {
-		h.logger.Warn("Invalid request body", "error", err.Error())
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
+	if err := bindJSON(c, &req); err != nil {
+		requestLogger(c, h.logger).Warn("Invalid request body", "error", err.Error())
+		return err
 	}
 
-	user, err := h.userService.UpdateUser(uint(id), &req)
+	user, err := h.userService.UpdateUser(reqctx.From(c), uint(id), &req)
 	if err != nil {
-		h.logger.Error("Failed to update user", "error", err.Error(), "user_id", id)
-		return utils.ErrorResponse(c, fiber.StatusNotFound, err.Error())
+		requestLogger(c, h.logger).Warn("Failed to update user", "error", err.Error(), "user_id", id)
+		return err
 	}
 
-	h.logger.Info("User updated successfully", "user_id", user.UserID)
+	requestLogger(c, h.logger).Info("User updated successfully", "user_id", user.UserID)
 	return utils.SuccessResponse(c, user)
 }
 
+// ExportMyData 导出当前用户的个人数据 GET /user/me/export
+func (h *UserHandler) ExportMyData(c fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return apperrors.ErrAuthRequired
+	}
+
+	export, err := h.exportService.ExportUserData(reqctx.From(c), userID)
+	if err != nil {
+		return err
+	}
+
+	requestLogger(c, h.logger).Info("User data exported", "user_id", userID)
+	c.Attachment(fmt.Sprintf("mcpforge-export-%d.json", userID))
+	return c.JSON(export)
+}
+
 // DeleteUser 删除用户 DELETE /user/:id
 func (h *UserHandler) DeleteUser(c fiber.Ctx) error {
-	h.logger.Info("Delete user requested", "method", c.Method(), "path", c.Path())
-
 	idParam := c.Params("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid user ID")
+		return apperrors.BadRequest("Invalid user ID")
+	}
+	if !canAccessAccount(c, uint(id)) {
+		return apperrors.ErrNotAccountOwner
 	}
 
-	err = h.userService.DeleteUser(uint(id))
+	err = h.userService.DeleteUser(reqctx.From(c), uint(id))
 	if err != nil {
-		h.logger.Error("Failed to delete user", "error", err.Error(), "user_id", id)
-		return utils.ErrorResponse(c, fiber.StatusNotFound, err.Error())
+		requestLogger(c, h.logger).Warn("Failed to delete user", "error", err.Error(), "user_id", id)
+		return err
 	}
 
-	h.logger.Info("User deleted successfully", "user_id", id)
-	return utils.SuccessResponse(c, fiber.Map{
-		"message": "User deleted successfully",
+	requestLogger(c, h.logger).Info("User deleted successfully", "user_id", id)
+	return utils.SuccessResponse(c, models.DeleteUserResponse{
+		Message:      "User deleted successfully",
+		RestoreUntil: time.Now().AddDate(0, 0, h.config.Account.DeletionGraceDays).Unix(),
 	})
 }
\ No newline at end of file
@@ -4,9 +4,12 @@ import (
 	"time"
 	
 	"github.com/gofiber/fiber/v3"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/reqctx"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
 )
@@ -29,78 +32,83 @@ func NewWeb3Handler(cfg *config.Config, l *logger.Logger, userService *services.
 
 // GetWeb3Challenge 获取Web3挑战 GET /user/auth/web3/challenge
 func (h *Web3Handler) GetWeb3Challenge(c fiber.Ctx) error {
-	h.logger.Info("Web3 challenge requested", "method", c.Method(), "path", c.Path())
-
-	// 获取地址参数
-	address := c.Query("address")
-	if address == "" {
-		h.logger.Warn("Missing address parameter")
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Address parameter is required")
-	}
-
-	// 创建请求结构
-	req := &models.Web3ChallengeRequest{
-		Address: address,
+	// 解析并校验地址参数
+	var req models.Web3ChallengeRequest
+	if err := bindQuery(c, &req); err != nil {
+		requestLogger(c, h.logger).Warn("Invalid address parameter", "error", err.Error())
+		return err
 	}
 
 	// 生成挑战
-	response, err := h.userService.GenerateWeb3Challenge(req.Address)
+	response, err := h.userService.GenerateWeb3Challenge(reqctx.From(c), req.Address)
 	if err != nil {
-		h.logger.Error("Failed to generate Web3 challenge", "error", err.Error(), "address", address)
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to generate Web3 challenge")
+		requestLogger(c, h.logger).Warn("Failed to generate Web3 challenge", "error", err.Error(), "address", req.Address)
+		return err
 	}
 
-	h.logger.Info("Web3 challenge generated successfully", "address", address)
+	requestLogger(c, h.logger).Debug("Web3 challenge generated successfully", "address", req.Address)
 	return utils.SuccessResponse(c, response)
 }
 
 // VerifyWeb3Auth 验证Web3认证 POST /user/auth/web3/verify
 func (h *Web3Handler) VerifyWeb3Auth(c fiber.Ctx) error {
-	h.logger.Info("Web3 auth verification requested", "method", c.Method(), "path", c.Path())
-
 	// 解析请求体
 	var req models.Web3AuthRequest
-	if err := c.Bind().JSON(&req); err != nil {
-		h.logger.Warn("Invalid request body", "error", err.Error())
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Invalid request body")
-	}
-
-	// 基础验证
-	if req.Address == "" || req.Signature == "" || req.Nonce == "" {
-		h.logger.Warn("Missing required fields", "address", req.Address != "", "signature", req.Signature != "", "nonce", req.Nonce != "")
-		return utils.ErrorResponse(c, fiber.StatusBadRequest, "Address, signature, and nonce are required")
+	if err := bindJSON(c, &req); err != nil {
+		requestLogger(c, h.logger).Warn("Invalid request body", "error", err.Error())
+		return err
 	}
 
 	// 验证Web3认证
-	response, err := h.userService.VerifyWeb3Auth(&req)
+	response, err := h.userService.VerifyWeb3Auth(reqctx.From(c), &req)
 	if err != nil {
-		h.logger.Error("Web3 auth verification failed", "error", err.Error(), "address", req.Address)
-		return utils.ErrorResponse(c, fiber.StatusUnauthorized, err.Error())
+		requestLogger(c, h.logger).Warn("Web3 auth verification failed", "error", err.Error(), "address", req.Address)
+		return err
 	}
 
 	// 生成JWT令牌
 	jwtUtil := utils.NewJWTUtil(h.config)
 	token, err := jwtUtil.GenerateToken(response.User.UserID, response.User.Username, string(response.User.Role))
 	if err != nil {
-		h.logger.Error("Failed to generate JWT token", "error", err.Error(), "user_id", response.User.UserID)
-		return utils.ErrorResponse(c, fiber.StatusInternalServerError, "Failed to generate authentication token")
+		return apperrors.Internal(err).WithMessage("Failed to generate authentication token")
 	}
 
 	// 设置HttpOnly cookie
+	c.Locals(string(middleware.IssuedTokenKey), token)
 	c.Cookie(&fiber.Cookie{
-		Name:     "auth_token",
+		Name:     middleware.AuthCookieName,
 		Value:    token,
-		Expires:  time.Now().Add(time.Duration(h.config.JWTExpiresIn) * time.Hour),
+		Expires:  time.Now().Add(time.Duration(h.config.Auth.JWTExpiresHours) * time.Hour),
 		HTTPOnly: true,
-		Secure:   h.config.Env == "production",
+		Secure:   h.config.IsProduction(),
 		SameSite: "lax",
 		Path:     "/",
 	})
 
-	h.logger.Info("Web3 auth verification successful", 
+	requestLogger(c, h.logger).Info("Web3 auth verification successful", 
 		"address", req.Address, 
 		"action", response.Action, 
 		"user_id", response.User.UserID)
 
 	return utils.SuccessResponse(c, response)
-}
\ No newline at end of file
+}
+
+// Logout 登出并清除认证cookie POST /user/auth/logout
+func (h *Web3Handler) Logout(c fiber.Ctx) error {
+	c.Cookie(&fiber.Cookie{
+		Name:     middleware.AuthCookieName,
+		Value:    "",
+		Expires:  time.Unix(0, 0),
+		HTTPOnly: true,
+		Secure:   h.config.IsProduction(),
+		SameSite: "lax",
+		Path:     "/",
+	})
+
+	userID, _ := middleware.GetUserID(c)
+	requestLogger(c, h.logger).Info("User logged out", "user_id", userID)
+
+	return utils.SuccessResponse(c, models.MessageResponse{
+		Message: "Logged out successfully",
+	})
+}
@@ -0,0 +1,133 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/tracing"
+)
+
+// Database 检查数据库连接池是否可用
+func Database(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// ChainRPC 调用 eth_chainId，expectedChainID 不为0时校验链ID
+func ChainRPC(rpcURL string, expectedChainID int) CheckFunc {
+	return func(ctx context.Context) error {
+		chainID, err := FetchChainID(ctx, rpcURL)
+		if err != nil {
+			return err
+		}
+		if expectedChainID != 0 && chainID != int64(expectedChainID) {
+			return fmt.Errorf("node reports chain id %d, expected %d", chainID, expectedChainID)
+		}
+		return nil
+	}
+}
+
+// FetchChainID 通过 eth_chainId 获取节点的链ID
+func FetchChainID(ctx context.Context, rpcURL string) (int64, error) {
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.New("invalid rpc url")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := tracing.HTTPClient.Do(req)
+	if err != nil {
+		return 0, redactURLError(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var result struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("decode response: %w", err)
+	}
+	if result.Error != nil {
+		return 0, errors.New(result.Error.Message)
+	}
+
+	chainID, err := strconv.ParseInt(strings.TrimPrefix(result.Result, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid chain id %q", result.Result)
+	}
+	return chainID, nil
+}
+
+// Kubernetes 使用token访问API服务器的 /version
+func Kubernetes(apiHost, bearerToken string) CheckFunc {
+	return func(ctx context.Context) error {
+		_, err := FetchKubernetesVersion(ctx, apiHost, bearerToken)
+		return err
+	}
+}
+
+// FetchKubernetesVersion 获取API服务器版本
+func FetchKubernetesVersion(ctx context.Context, apiHost, bearerToken string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(apiHost, "/")+"/version", nil)
+	if err != nil {
+		return "", errors.New("invalid kubernetes api host")
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := tracing.HTTPClient.Do(req)
+	if err != nil {
+		return "", redactURLError(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var version struct {
+		GitVersion string `json:"gitVersion"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", fmt.Errorf("decode response: %w", err)
+	}
+	return version.GitVersion, nil
+}
+
+// TCP 检查地址是否可以建立TCP连接，用于SMTP等服务
+func TCP(addr string) CheckFunc {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// redactURLError RPC和Kubernetes地址可能包含API密钥或内部主机名，错误信息中只保留底层原因
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
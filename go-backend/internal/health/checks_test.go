@@ -0,0 +1,53 @@
+package health
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestFetchKubernetesVersionRedactsHost(t *testing.T) {
+	// 监听后立即关闭，保证连接被拒绝
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	apiHost := "http://k8s-user:k8s-secret@" + addr + "/cluster-a"
+	_, err = FetchKubernetesVersion(context.Background(), apiHost, "token")
+	if err == nil {
+		t.Fatal("expected a connection error")
+	}
+	for _, leaked := range []string{"k8s-secret", "/cluster-a", "http://"} {
+		if strings.Contains(err.Error(), leaked) {
+			t.Errorf("error %q contains %q", err, leaked)
+		}
+	}
+
+	if _, err := FetchKubernetesVersion(context.Background(), "http://bad host\x7f", ""); err == nil || strings.Contains(err.Error(), "bad host") {
+		t.Errorf("invalid host error = %v", err)
+	}
+}
+
+func TestReportPublic(t *testing.T) {
+	report := Report{Status: StatusDegraded, Checks: []Result{
+		{Name: "database", Status: StatusUp, Critical: true},
+		{Name: "kubernetes", Status: StatusDown, Error: "dial tcp 10.0.0.1:6443: connection refused"},
+	}}
+	public := report.Public()
+	if public.Status != StatusDegraded || len(public.Checks) != 2 || public.Checks[1].Status != StatusDown {
+		t.Fatalf("public report = %+v", public)
+	}
+	for _, res := range public.Checks {
+		if res.Error != "" {
+			t.Errorf("%s error exposed: %q", res.Name, res.Error)
+		}
+	}
+	// 原报告保留错误，用于写入日志
+	if report.Checks[1].Error == "" {
+		t.Error("Public modified the original report")
+	}
+}
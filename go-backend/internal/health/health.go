@@ -0,0 +1,205 @@
+// Package health 维护组件健康检查，供 /livez、/readyz 与运维命令使用
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout 未指定超时时单项检查的超时时间
+const DefaultTimeout = 2 * time.Second
+
+// Status 检查结果状态
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// CheckFunc 检查函数，返回nil表示组件正常
+type CheckFunc func(ctx context.Context) error
+
+// Check 注册到Registry的一项检查
+type Check struct {
+	Name    string
+	Timeout time.Duration
+	// Critical 失败时服务不可用，否则只将整体状态标记为降级
+	Critical bool
+	// Liveness 同时用于存活检查，只应注册不依赖外部服务的检查
+	Liveness bool
+	Run      CheckFunc
+}
+
+// Result 单项检查结果
+type Result struct {
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	Critical   bool   `json:"critical"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Report 汇总后的检查报告
+type Report struct {
+	Status Status   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Public 去掉检查的错误信息，供无需认证的探针接口返回。
+// 错误中可能包含内部地址，只写入日志
+func (r Report) Public() Report {
+	checks := make([]Result, len(r.Checks))
+	for i, res := range r.Checks {
+		res.Error = ""
+		checks[i] = res
+	}
+	return Report{Status: r.Status, Checks: checks}
+}
+
+// OK 整体状态不是down时服务可用
+func (r Report) OK() bool {
+	return r.Status != StatusDown
+}
+
+// errShuttingDown 服务正在关闭时就绪检查直接失败
+var errShuttingDown = errors.New("server is shutting down")
+
+// Registry 健康检查注册表
+type Registry struct {
+	mu           sync.RWMutex
+	checks       []Check
+	startedAt    time.Time
+	shuttingDown atomic.Bool
+}
+
+// NewRegistry 创建注册表，以当前时间作为启动时间
+func NewRegistry() *Registry {
+	return &Registry{startedAt: time.Now()}
+}
+
+// Register 注册检查，同名检查会被替换
+func (r *Registry) Register(check Check) {
+	if check.Timeout <= 0 {
+		check.Timeout = DefaultTimeout
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.checks {
+		if r.checks[i].Name == check.Name {
+			r.checks[i] = check
+			return
+		}
+	}
+	r.checks = append(r.checks, check)
+}
+
+// StartedAt 启动时间
+func (r *Registry) StartedAt() time.Time {
+	return r.startedAt
+}
+
+// Uptime 已运行时长
+func (r *Registry) Uptime() time.Duration {
+	return time.Since(r.startedAt)
+}
+
+// MarkShuttingDown 标记服务正在关闭，此后就绪检查返回down
+func (r *Registry) MarkShuttingDown() {
+	r.shuttingDown.Store(true)
+}
+
+// ShuttingDown 服务是否正在关闭
+func (r *Registry) ShuttingDown() bool {
+	return r.shuttingDown.Load()
+}
+
+// Liveness 只运行标记为存活检查的项目
+func (r *Registry) Liveness(ctx context.Context) Report {
+	return r.run(ctx, func(c Check) bool { return c.Liveness })
+}
+
+// Readiness 运行全部检查，服务关闭期间直接返回down
+func (r *Registry) Readiness(ctx context.Context) Report {
+	if r.ShuttingDown() {
+		return Report{
+			Status: StatusDown,
+			Checks: []Result{{Name: "shutdown", Status: StatusDown, Critical: true, Error: errShuttingDown.Error()}},
+		}
+	}
+	return r.run(ctx, func(Check) bool { return true })
+}
+
+// run 并发执行符合条件的检查并汇总状态
+func (r *Registry) run(ctx context.Context, include func(Check) bool) Report {
+	r.mu.RLock()
+	var checks []Check
+	for _, c := range r.checks {
+		if include(c) {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Go(func() { results[i] = runCheck(ctx, c) })
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: results}
+	for _, res := range results {
+		if res.Status != StatusDown {
+			continue
+		}
+		if res.Critical {
+			report.Status = StatusDown
+			break
+		}
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+// runCheck 在超时内执行单项检查，检查函数卡住时按超时处理
+func runCheck(ctx context.Context, c Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("check panicked: %v", p)
+			}
+		}()
+		done <- c.Run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", c.Timeout)
+	}
+
+	res := Result{
+		Name:       c.Name,
+		Status:     StatusUp,
+		Critical:   c.Critical,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
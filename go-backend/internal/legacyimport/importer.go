@@ -0,0 +1,323 @@
+package legacyimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// ErrConflicts 导入会违反目标库的唯一约束
+var ErrConflicts = errors.New("import has conflicts with existing target rows")
+
+// TableDiff 单张表的导入差异
+type TableDiff struct {
+	Table     string
+	Source    int
+	Target    int
+	Inserts   []uint
+	Updates   []uint
+	Unchanged int
+	// Extra 目标库中存在但旧库中没有的行
+	Extra []uint
+	// Conflicts 与目标库中其他行的唯一键冲突
+	Conflicts []string
+}
+
+// Plan 导入计划，即旧库与目标库之间的差异
+type Plan struct {
+	Tables []TableDiff
+	Notes  []string
+
+	pending pendingRows
+}
+
+// pendingRows 需要写入目标库的行
+type pendingRows struct {
+	users       []models.User
+	authMethods []models.AuthMethod
+	cards       []models.MCPCard
+	servers     []models.MCPServer
+}
+
+// HasChanges 是否有需要写入的行
+func (p *Plan) HasChanges() bool {
+	for _, t := range p.Tables {
+		if len(t.Inserts) > 0 || len(t.Updates) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasConflicts 是否存在唯一键冲突
+func (p *Plan) HasConflicts() bool {
+	for _, t := range p.Tables {
+		if len(t.Conflicts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Verified 目标库是否已包含旧库的全部数据且内容一致
+func (p *Plan) Verified() bool {
+	return !p.HasChanges() && !p.HasConflicts()
+}
+
+// Importer 将NestJS/TypeORM旧库的数据导入Go数据库
+type Importer struct {
+	source *gorm.DB
+	target *gorm.DB
+}
+
+// New 创建导入器
+func New(source, target *gorm.DB) *Importer {
+	return &Importer{
+		source: source,
+		target: target,
+	}
+}
+
+// Plan 读取两端数据并计算差异，不写入任何数据。
+// 两端数据全部载入内存，适用于一次性迁移规模的数据量
+func (i *Importer) Plan() (*Plan, error) {
+	data, err := loadLegacy(i.source)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []models.User
+	if err := i.target.Unscoped().Order("user_id").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("read target users: %w", err)
+	}
+	var authMethods []models.AuthMethod
+	if err := i.target.Order("auth_id").Find(&authMethods).Error; err != nil {
+		return nil, fmt.Errorf("read target auth methods: %w", err)
+	}
+	var cards []models.MCPCard
+	if err := i.target.Order("id").Find(&cards).Error; err != nil {
+		return nil, fmt.Errorf("read target mcp cards: %w", err)
+	}
+	var servers []models.MCPServer
+	if err := i.target.Order("id").Find(&servers).Error; err != nil {
+		return nil, fmt.Errorf("read target mcp servers: %w", err)
+	}
+
+	plan := &Plan{Notes: data.notes}
+
+	diff, rows := diffTable("users", data.users, users,
+		func(u *models.User) uint { return u.UserID },
+		func(u *models.User) string { return u.Username },
+		usersEqual)
+	plan.Tables = append(plan.Tables, diff)
+	plan.pending.users = rows
+
+	diff, methodRows := diffTable("auth_methods", data.authMethods, authMethods,
+		func(m *models.AuthMethod) uint { return m.AuthID },
+		func(m *models.AuthMethod) string { return string(m.AuthType) + ":" + m.AuthIdentifier },
+		authMethodsEqual)
+	plan.Tables = append(plan.Tables, diff)
+	plan.pending.authMethods = methodRows
+
+	diff, cardRows := diffTable("mcp_cards", data.cards, cards,
+		func(c *models.MCPCard) uint { return c.ID },
+		nil,
+		cardsEqual)
+	plan.Tables = append(plan.Tables, diff)
+	plan.pending.cards = cardRows
+
+	diff, serverRows := diffTable("mcp_servers", data.servers, servers,
+		func(s *models.MCPServer) uint { return s.ID },
+		func(s *models.MCPServer) string { return s.Name },
+		serversEqual)
+	plan.Tables = append(plan.Tables, diff)
+	plan.pending.servers = serverRows
+
+	return plan, nil
+}
+
+// Apply 在单个事务中写入计划中的差异。行按主键upsert，重复执行是幂等的
+func (i *Importer) Apply(plan *Plan) error {
+	if plan.HasConflicts() {
+		return ErrConflicts
+	}
+	if !plan.HasChanges() {
+		return nil
+	}
+
+	return i.target.Transaction(func(tx *gorm.DB) error {
+		tx = tx.Omit(clause.Associations)
+
+		if err := upsert(tx, plan.pending.users, "user_id",
+			"username", "email", "role", "reward_address", "created_at", "updated_at"); err != nil {
+			return fmt.Errorf("write users: %w", err)
+		}
+		if err := upsert(tx, plan.pending.authMethods, "auth_id",
+			"user_id", "auth_type", "auth_identifier", "created_at"); err != nil {
+			return fmt.Errorf("write auth methods: %w", err)
+		}
+		if err := upsert(tx, plan.pending.cards, "id",
+			"name", "tags", "github_url", "author", "description", "overview", "tools",
+			"price", "configs", "docker_image", "created_at", "updated_at"); err != nil {
+			return fmt.Errorf("write mcp cards: %w", err)
+		}
+		if err := upsert(tx, plan.pending.servers, "id",
+			"name", "image", "status", "created_at", "updated_at"); err != nil {
+			return fmt.Errorf("write mcp servers: %w", err)
+		}
+
+		// 保留了旧库的主键，需要把序列推进到最大值之后。
+		// SQLite 的 AUTOINCREMENT 在写入显式主键时会自动更新 sqlite_sequence
+		if tx.Dialector.Name() != "postgres" {
+			return nil
+		}
+		for table, column := range map[string]string{
+			"users":        "user_id",
+			"auth_methods": "auth_id",
+			"mcp_cards":    "id",
+			"mcp_servers":  "id",
+		} {
+			sql := fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('%[1]s', '%[2]s'), COALESCE(MAX(%[2]s), 0) + 1, false) FROM %[1]s`,
+				table, column)
+			if err := tx.Exec(sql).Error; err != nil {
+				return fmt.Errorf("reset %s sequence: %w", table, err)
+			}
+		}
+		return nil
+	})
+}
+
+// upsert 按主键写入行，冲突时只更新指定的列。
+// 不使用UpdateAll，否则gorm会把updated_at改写为当前时间，导致重复执行产生差异
+func upsert[T any](tx *gorm.DB, rows []T, pk string, columns ...string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: pk}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).CreateInBatches(rows, 500).Error
+}
+
+// diffTable 按主键比较两端数据，返回差异和需要写入的行
+func diffTable[T any](table string, source, target []T, id func(*T) uint, uniqueKey func(*T) string, equal func(a, b *T) bool) (TableDiff, []T) {
+	diff := TableDiff{Table: table, Source: len(source), Target: len(target)}
+
+	targetByID := make(map[uint]*T, len(target))
+	targetByKey := make(map[string]uint, len(target))
+	for i := range target {
+		row := &target[i]
+		targetByID[id(row)] = row
+		if uniqueKey != nil {
+			targetByKey[uniqueKey(row)] = id(row)
+		}
+	}
+
+	sourceIDs := make(map[uint]bool, len(source))
+	var pending []T
+	for i := range source {
+		row := &source[i]
+		rowID := id(row)
+		sourceIDs[rowID] = true
+
+		if uniqueKey != nil {
+			if otherID, ok := targetByKey[uniqueKey(row)]; ok && otherID != rowID {
+				diff.Conflicts = append(diff.Conflicts,
+					fmt.Sprintf("source %d and target %d share unique key %q", rowID, otherID, uniqueKey(row)))
+				continue
+			}
+		}
+
+		existing, ok := targetByID[rowID]
+		switch {
+		case !ok:
+			diff.Inserts = append(diff.Inserts, rowID)
+			pending = append(pending, *row)
+		case !equal(row, existing):
+			diff.Updates = append(diff.Updates, rowID)
+			pending = append(pending, *row)
+		default:
+			diff.Unchanged++
+		}
+	}
+
+	for i := range target {
+		if rowID := id(&target[i]); !sourceIDs[rowID] {
+			diff.Extra = append(diff.Extra, rowID)
+		}
+	}
+
+	return diff, pending
+}
+
+func usersEqual(a, b *models.User) bool {
+	return a.Username == b.Username &&
+		equalPtr(a.Email, b.Email) &&
+		a.Role == b.Role &&
+		equalPtr(a.RewardAddress, b.RewardAddress) &&
+		equalTime(a.CreatedAt, b.CreatedAt) &&
+		equalTime(a.UpdatedAt, b.UpdatedAt)
+}
+
+func authMethodsEqual(a, b *models.AuthMethod) bool {
+	return a.UserID == b.UserID &&
+		a.AuthType == b.AuthType &&
+		a.AuthIdentifier == b.AuthIdentifier &&
+		equalTime(a.CreatedAt, b.CreatedAt)
+}
+
+func cardsEqual(a, b *models.MCPCard) bool {
+	return equalPtr(a.Name, b.Name) &&
+		slices.Equal(a.Tags, b.Tags) &&
+		a.GithubURL == b.GithubURL &&
+		equalPtr(a.Author, b.Author) &&
+		equalPtr(a.Description, b.Description) &&
+		equalPtr(a.Overview, b.Overview) &&
+		equalPtr(a.Tools, b.Tools) &&
+		equalPtr(a.Price, b.Price) &&
+		equalJSON(a.Configs, b.Configs) &&
+		equalPtr(a.DockerImage, b.DockerImage) &&
+		equalTime(a.CreatedAt, b.CreatedAt) &&
+		equalTime(a.UpdatedAt, b.UpdatedAt)
+}
+
+func serversEqual(a, b *models.MCPServer) bool {
+	return a.Name == b.Name &&
+		a.Image == b.Image &&
+		equalJSON(a.Status, b.Status) &&
+		equalTime(a.CreatedAt, b.CreatedAt) &&
+		equalTime(a.UpdatedAt, b.UpdatedAt)
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// equalTime 旧库为不带时区的timestamp，按微秒精度比较
+func equalTime(a, b time.Time) bool {
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+// equalJSON 旧库为json列保留原文，目标库为jsonb会重排键，按语义比较
+func equalJSON(a, b json.RawMessage) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,345 @@
+package legacyimport_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/database"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/legacyimport"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/migrations"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// legacySchema NestJS/TypeORM 旧库的表结构，enum和json列在SQLite中用TEXT表示
+const legacySchema = `
+CREATE TABLE users (
+    user_id        INTEGER PRIMARY KEY,
+    username       TEXT NOT NULL,
+    email          TEXT,
+    role           TEXT NOT NULL DEFAULT 'user',
+    reward_address TEXT,
+    created_at     DATETIME NOT NULL,
+    updated_at     DATETIME NOT NULL
+);
+CREATE TABLE auth_methods (
+    auth_id         INTEGER PRIMARY KEY,
+    user_id         INTEGER NOT NULL,
+    auth_type       TEXT NOT NULL,
+    auth_identifier TEXT NOT NULL,
+    created_at      DATETIME NOT NULL
+);`
+
+const legacyMCPSchema = `
+CREATE TABLE mcp_card (
+    id           INTEGER PRIMARY KEY,
+    name         TEXT,
+    tags         TEXT,
+    github_url   TEXT NOT NULL,
+    author       TEXT,
+    description  TEXT,
+    overview     TEXT,
+    tools        TEXT,
+    price        NUMERIC(10, 2),
+    configs      TEXT,
+    docker_image TEXT,
+    created_at   DATETIME NOT NULL,
+    updated_at   DATETIME NOT NULL
+);
+CREATE TABLE mcp_server (
+    id         INTEGER PRIMARY KEY,
+    name       TEXT NOT NULL UNIQUE,
+    image      TEXT NOT NULL,
+    status     TEXT,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);`
+
+var legacyTime = time.Date(2025, 3, 1, 8, 30, 0, 123456000, time.UTC)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.Open(config.DatabaseConfig{Driver: config.DatabaseDriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close(db) })
+	return db
+}
+
+// newSource 创建旧库，withMCP 为false时模拟没有 mcp_card 和 mcp_server 表的旧库
+func newSource(t *testing.T, withMCP bool) *gorm.DB {
+	t.Helper()
+	db := openSQLite(t)
+	exec(t, db, legacySchema)
+	if withMCP {
+		exec(t, db, legacyMCPSchema)
+	}
+	return db
+}
+
+// newTarget 创建已执行全部迁移的Go数据库
+func newTarget(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := openSQLite(t)
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func exec(t *testing.T, db *gorm.DB, sql string, args ...any) {
+	t.Helper()
+	if err := db.Exec(sql, args...).Error; err != nil {
+		t.Fatalf("%s: %v", sql, err)
+	}
+}
+
+func addUser(t *testing.T, db *gorm.DB, id uint, username, role string) {
+	t.Helper()
+	exec(t, db, `INSERT INTO users (user_id, username, email, role, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		id, username, username+"@example.com", role, legacyTime, legacyTime)
+}
+
+func addAuthMethod(t *testing.T, db *gorm.DB, id, userID uint, authType, identifier string) {
+	t.Helper()
+	exec(t, db, `INSERT INTO auth_methods (auth_id, user_id, auth_type, auth_identifier, created_at) VALUES (?, ?, ?, ?, ?)`,
+		id, userID, authType, identifier, legacyTime)
+}
+
+// seedLegacy 写入覆盖所有映射规则的旧数据
+func seedLegacy(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	addUser(t, db, 1, "alice", "user")
+	addUser(t, db, 2, "bob", "developer")
+	addUser(t, db, 3, "alice", "user")      // 重复的用户名
+	addUser(t, db, 4, "carol", "moderator") // 未知角色
+
+	addAuthMethod(t, db, 10, 1, "web3", "0xAbCdEf0000000000000000000000000000000001")
+	addAuthMethod(t, db, 11, 2, "github", "BobOnGitHub")
+	addAuthMethod(t, db, 12, 3, "web3", "0xabcdef0000000000000000000000000000000001")  // 转为小写后与10重复
+	addAuthMethod(t, db, 13, 99, "web3", "0x0000000000000000000000000000000000000099") // 用户不存在
+
+	exec(t, db, `INSERT INTO mcp_card (id, name, tags, github_url, price, configs, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		7, "weather", `["tools","weather"]`, "https://github.com/example/weather", "9.99", `{"b":2,"a":1}`, legacyTime, legacyTime)
+	exec(t, db, `INSERT INTO mcp_server (id, name, image, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		5, "weather-1", "example/weather:1", `{"phase":"Running"}`, legacyTime, legacyTime)
+}
+
+func tableDiff(t *testing.T, plan *legacyimport.Plan, table string) legacyimport.TableDiff {
+	t.Helper()
+	for _, diff := range plan.Tables {
+		if diff.Table == table {
+			return diff
+		}
+	}
+	t.Fatalf("no diff for table %s", table)
+	return legacyimport.TableDiff{}
+}
+
+func hasNote(plan *legacyimport.Plan, substr string) bool {
+	return slices.ContainsFunc(plan.Notes, func(note string) bool { return strings.Contains(note, substr) })
+}
+
+func TestPlanMapsLegacyRows(t *testing.T) {
+	source, target := newSource(t, true), newTarget(t)
+	seedLegacy(t, source)
+
+	plan, err := legacyimport.New(source, target).Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for table, want := range map[string][]uint{
+		"users":        {1, 2, 3, 4},
+		"auth_methods": {10, 11},
+		"mcp_cards":    {7},
+		"mcp_servers":  {5},
+	} {
+		if got := tableDiff(t, plan, table).Inserts; !slices.Equal(got, want) {
+			t.Errorf("%s inserts = %v, want %v", table, got, want)
+		}
+	}
+	for _, note := range []string{
+		`user 3: duplicate username "alice" renamed to "alice-3"`,
+		`user 4: unknown role "moderator" mapped to "user"`,
+		"auth method 12: duplicates auth method 10",
+		"auth method 13: user 99 does not exist",
+	} {
+		if !hasNote(plan, note) {
+			t.Errorf("missing note %q in %q", note, plan.Notes)
+		}
+	}
+	if !plan.HasChanges() || plan.HasConflicts() || plan.Verified() {
+		t.Errorf("unexpected plan state: changes=%v conflicts=%v", plan.HasChanges(), plan.HasConflicts())
+	}
+
+	// dry-run 不写入目标库
+	var count int64
+	target.Model(&models.User{}).Count(&count)
+	if count != 0 {
+		t.Errorf("plan wrote %d users", count)
+	}
+}
+
+func TestApplyImportsAndIsIdempotent(t *testing.T) {
+	source, target := newSource(t, true), newTarget(t)
+	seedLegacy(t, source)
+	importer := legacyimport.New(source, target)
+
+	plan, err := importer.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := importer.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := importer.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Verified() {
+		t.Fatalf("verification failed after apply: %+v", report.Tables)
+	}
+	if diff := tableDiff(t, report, "users"); diff.Unchanged != 4 || diff.Target != 4 {
+		t.Errorf("users report = %+v", diff)
+	}
+	// 重复执行没有变化
+	if err := importer.Apply(report); err != nil {
+		t.Fatal(err)
+	}
+
+	var users []models.User
+	if err := target.Order("user_id").Find(&users).Error; err != nil {
+		t.Fatal(err)
+	}
+	gotNames := make([]string, len(users))
+	for i, u := range users {
+		gotNames[i] = u.Username + ":" + string(u.Role)
+	}
+	if want := []string{"alice:user", "bob:developer", "alice-3:user", "carol:user"}; !slices.Equal(gotNames, want) {
+		t.Errorf("users = %v, want %v", gotNames, want)
+	}
+	if !users[0].CreatedAt.Equal(legacyTime) {
+		t.Errorf("created_at = %v, want %v", users[0].CreatedAt, legacyTime)
+	}
+
+	var methods []models.AuthMethod
+	if err := target.Order("auth_id").Find(&methods).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(methods) != 2 || methods[0].AuthIdentifier != "0xabcdef0000000000000000000000000000000001" || methods[1].AuthIdentifier != "BobOnGitHub" {
+		t.Errorf("auth methods = %+v", methods)
+	}
+
+	var card models.MCPCard
+	if err := target.First(&card, 7).Error; err != nil {
+		t.Fatal(err)
+	}
+	var configs map[string]int
+	if err := json.Unmarshal(card.Configs, &configs); err != nil || configs["a"] != 1 || configs["b"] != 2 {
+		t.Errorf("card configs = %s (%v)", card.Configs, err)
+	}
+	if !slices.Equal(card.Tags, []string{"tools", "weather"}) || card.Price == nil || *card.Price != "9.99" {
+		t.Errorf("card = %+v", card)
+	}
+
+	// 保留旧库主键后，新注册的用户从最大ID之后分配
+	next := models.User{Username: "dave", Role: models.UserRoleUser}
+	if err := target.Create(&next).Error; err != nil {
+		t.Fatal(err)
+	}
+	if next.UserID != 5 {
+		t.Errorf("new user id = %d, want 5", next.UserID)
+	}
+}
+
+func TestPlanDetectsUpdatesAndExtraRows(t *testing.T) {
+	source, target := newSource(t, true), newTarget(t)
+	seedLegacy(t, source)
+	importer := legacyimport.New(source, target)
+
+	plan, err := importer.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := importer.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+
+	exec(t, source, `UPDATE users SET email = 'bob@new.example.com' WHERE user_id = 2`)
+	exec(t, target, `INSERT INTO users (user_id, username, role, created_at, updated_at) VALUES (50, 'go-only', 'user', ?, ?)`, legacyTime, legacyTime)
+
+	plan, err = importer.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := tableDiff(t, plan, "users")
+	if !slices.Equal(diff.Updates, []uint{2}) || !slices.Equal(diff.Extra, []uint{50}) || len(diff.Inserts) != 0 {
+		t.Errorf("users diff = %+v", diff)
+	}
+	if err := importer.Apply(plan); err != nil {
+		t.Fatal(err)
+	}
+
+	var bob models.User
+	if err := target.First(&bob, 2).Error; err != nil {
+		t.Fatal(err)
+	}
+	if bob.Email == nil || *bob.Email != "bob@new.example.com" {
+		t.Errorf("email not updated: %v", bob.Email)
+	}
+	// 目标库独有的行不会被删除
+	var count int64
+	target.Model(&models.User{}).Where("user_id = ?", 50).Count(&count)
+	if count != 1 {
+		t.Error("extra target row was removed")
+	}
+}
+
+func TestApplyRefusesConflicts(t *testing.T) {
+	source, target := newSource(t, true), newTarget(t)
+	addUser(t, source, 1, "alice", "user")
+	exec(t, target, `INSERT INTO users (user_id, username, role, created_at, updated_at) VALUES (8, 'alice', 'user', ?, ?)`, legacyTime, legacyTime)
+
+	importer := legacyimport.New(source, target)
+	plan, err := importer.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := tableDiff(t, plan, "users"); len(diff.Conflicts) != 1 || len(diff.Inserts) != 0 {
+		t.Fatalf("users diff = %+v", diff)
+	}
+	if err := importer.Apply(plan); !errors.Is(err, legacyimport.ErrConflicts) {
+		t.Errorf("Apply = %v, want ErrConflicts", err)
+	}
+}
+
+func TestPlanSkipsMissingMCPTables(t *testing.T) {
+	source, target := newSource(t, false), newTarget(t)
+	addUser(t, source, 1, "alice", "user")
+
+	plan, err := legacyimport.New(source, target).Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range []string{"mcp_card", "mcp_server"} {
+		if !hasNote(plan, "table "+table+" not found in source") {
+			t.Errorf("missing skip note for %s: %q", table, plan.Notes)
+		}
+	}
+	if diff := tableDiff(t, plan, "mcp_cards"); diff.Source != 0 || len(diff.Inserts) != 0 {
+		t.Errorf("mcp_cards diff = %+v", diff)
+	}
+}
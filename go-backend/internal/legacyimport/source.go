@@ -0,0 +1,130 @@
+package legacyimport
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// 旧版NestJS/TypeORM数据库中的表名
+const (
+	legacyUsersTable       = "users"
+	legacyAuthMethodsTable = "auth_methods"
+	legacyCardsTable       = "mcp_card"
+	legacyServersTable     = "mcp_server"
+)
+
+// dataset 从旧库读取并映射为Go模型的数据
+type dataset struct {
+	users       []models.User
+	authMethods []models.AuthMethod
+	cards       []models.MCPCard
+	servers     []models.MCPServer
+
+	// notes 映射过程中对数据所做的调整
+	notes []string
+}
+
+// loadLegacy 读取旧库数据并按Go数据库的约束进行映射。
+// 映射规则是确定性的，因此重复执行得到相同的结果
+func loadLegacy(db *gorm.DB) (*dataset, error) {
+	data := &dataset{}
+
+	// 枚举列转为文本，与Go模型的varchar列对应。使用标准的CAST以便在SQLite上测试
+	err := db.Raw(`SELECT user_id, username, email, CAST(role AS TEXT) AS role, reward_address, created_at, updated_at
+		FROM ` + legacyUsersTable + ` ORDER BY user_id`).Scan(&data.users).Error
+	if err != nil {
+		return nil, fmt.Errorf("read legacy users: %w", err)
+	}
+
+	err = db.Raw(`SELECT auth_id, user_id, CAST(auth_type AS TEXT) AS auth_type, auth_identifier, created_at
+		FROM ` + legacyAuthMethodsTable + ` ORDER BY auth_id`).Scan(&data.authMethods).Error
+	if err != nil {
+		return nil, fmt.Errorf("read legacy auth methods: %w", err)
+	}
+
+	// mcp_card 和 mcp_server 由 synchronize 创建，旧库中可能不存在
+	if db.Migrator().HasTable(legacyCardsTable) {
+		err = db.Raw(`SELECT id, name, tags, github_url, author, description, overview, tools,
+			CAST(price AS TEXT) AS price, configs, docker_image, created_at, updated_at
+			FROM ` + legacyCardsTable + ` ORDER BY id`).Scan(&data.cards).Error
+		if err != nil {
+			return nil, fmt.Errorf("read legacy mcp cards: %w", err)
+		}
+	} else {
+		data.note("table %s not found in source, skipped", legacyCardsTable)
+	}
+
+	if db.Migrator().HasTable(legacyServersTable) {
+		err = db.Raw(`SELECT id, name, image, status, created_at, updated_at
+			FROM ` + legacyServersTable + ` ORDER BY id`).Scan(&data.servers).Error
+		if err != nil {
+			return nil, fmt.Errorf("read legacy mcp servers: %w", err)
+		}
+	} else {
+		data.note("table %s not found in source, skipped", legacyServersTable)
+	}
+
+	data.mapUsers()
+	data.mapAuthMethods()
+
+	return data, nil
+}
+
+func (d *dataset) note(format string, args ...any) {
+	d.notes = append(d.notes, fmt.Sprintf(format, args...))
+}
+
+// mapUsers 规范化角色；旧库用户名不唯一，重复的用户名追加用户ID后缀
+func (d *dataset) mapUsers() {
+	seen := make(map[string]bool, len(d.users))
+	for i := range d.users {
+		user := &d.users[i]
+
+		if user.Role != models.UserRoleUser && user.Role != models.UserRoleDeveloper {
+			d.note("user %d: unknown role %q mapped to %q", user.UserID, user.Role, models.UserRoleUser)
+			user.Role = models.UserRoleUser
+		}
+
+		if seen[user.Username] {
+			renamed := fmt.Sprintf("%s-%d", user.Username, user.UserID)
+			d.note("user %d: duplicate username %q renamed to %q", user.UserID, user.Username, renamed)
+			user.Username = renamed
+		}
+		seen[user.Username] = true
+	}
+}
+
+// mapAuthMethods 旧版按原始大小写存储钱包地址，Go版本按小写查找；
+// 转换后重复的认证方法以及没有对应用户的认证方法会被跳过
+func (d *dataset) mapAuthMethods() {
+	userIDs := make(map[uint]bool, len(d.users))
+	for _, user := range d.users {
+		userIDs[user.UserID] = true
+	}
+
+	seen := make(map[string]uint, len(d.authMethods))
+	kept := d.authMethods[:0]
+	for _, method := range d.authMethods {
+		if !userIDs[method.UserID] {
+			d.note("auth method %d: user %d does not exist, skipped", method.AuthID, method.UserID)
+			continue
+		}
+
+		if method.AuthType == models.AuthTypeWeb3 {
+			method.AuthIdentifier = strings.ToLower(method.AuthIdentifier)
+		}
+
+		key := string(method.AuthType) + ":" + method.AuthIdentifier
+		if first, ok := seen[key]; ok {
+			d.note("auth method %d: duplicates auth method %d (%s), skipped", method.AuthID, first, key)
+			continue
+		}
+		seen[key] = method.AuthID
+		kept = append(kept, method)
+	}
+	d.authMethods = kept
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// StatsSource 业务统计来源
+type StatsSource interface {
+	PlatformStats() (*models.PlatformStats, error)
+}
+
+// businessCollector 抓取时查询业务统计，结果缓存ttl以免频繁抓取压垮数据库。
+// 目前没有订阅模型，因此不提供活跃订阅数指标，加入订阅后在PlatformStats中补充
+type businessCollector struct {
+	source StatsSource
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	cached    *models.PlatformStats
+	fetchedAt time.Time
+
+	users           *prometheus.Desc
+	deployedServers *prometheus.Desc
+	publishedCards  *prometheus.Desc
+	pendingApps     *prometheus.Desc
+	pendingRewards  *prometheus.Desc
+	scrapeErrors    prometheus.Counter
+}
+
+// NewBusinessCollector 创建业务指标采集器
+func NewBusinessCollector(source StatsSource, ttl time.Duration) prometheus.Collector {
+	return &businessCollector{
+		source:          source,
+		ttl:             ttl,
+		users:           prometheus.NewDesc(namespace+"_users", "Active users by role.", []string{"role"}, nil),
+		deployedServers: prometheus.NewDesc(namespace+"_mcp_servers_deployed", "Deployed MCP servers.", nil, nil),
+		publishedCards:  prometheus.NewDesc(namespace+"_mcp_cards_published", "Published MCP marketplace cards.", nil, nil),
+		pendingApps:     prometheus.NewDesc(namespace+"_role_applications_pending", "Developer role applications awaiting review.", nil, nil),
+		pendingRewards:  prometheus.NewDesc(namespace+"_reward_address_changes_pending", "Reward address changes in their cooling-off period.", nil, nil),
+		scrapeErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "business_stats_errors_total",
+			Help:      "Failed queries for business statistics.",
+		}),
+	}
+}
+
+func (b *businessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- b.users
+	ch <- b.deployedServers
+	ch <- b.publishedCards
+	ch <- b.pendingApps
+	ch <- b.pendingRewards
+	b.scrapeErrors.Describe(ch)
+}
+
+func (b *businessCollector) Collect(ch chan<- prometheus.Metric) {
+	if stats := b.stats(); stats != nil {
+		for role, count := range stats.UsersByRole {
+			ch <- prometheus.MustNewConstMetric(b.users, prometheus.GaugeValue, float64(count), string(role))
+		}
+		ch <- prometheus.MustNewConstMetric(b.deployedServers, prometheus.GaugeValue, float64(stats.DeployedServers))
+		ch <- prometheus.MustNewConstMetric(b.publishedCards, prometheus.GaugeValue, float64(stats.PublishedCards))
+		ch <- prometheus.MustNewConstMetric(b.pendingApps, prometheus.GaugeValue, float64(stats.PendingRoleApplications))
+		ch <- prometheus.MustNewConstMetric(b.pendingRewards, prometheus.GaugeValue, float64(stats.PendingRewardChanges))
+	}
+	b.scrapeErrors.Collect(ch)
+}
+
+// stats 返回缓存的统计，过期时重新查询；查询失败时沿用上次结果
+func (b *businessCollector) stats() *models.PlatformStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.cached != nil && time.Since(b.fetchedAt) < b.ttl {
+		return b.cached
+	}
+	stats, err := b.source.PlatformStats()
+	if err != nil {
+		b.scrapeErrors.Inc()
+		return b.cached
+	}
+	b.cached, b.fetchedAt = stats, time.Now()
+	return stats
+}
@@ -0,0 +1,138 @@
+// Package metrics 以Prometheus格式暴露HTTP、数据库、认证和业务指标
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/buildinfo"
+)
+
+const namespace = "mcpforge"
+
+// unmatchedRoute 未匹配任何路由的请求统一使用的标签，避免任意路径造成标签爆炸
+const unmatchedRoute = "unmatched"
+
+// Metrics 持有独立的指标注册表，避免与全局注册表中的第三方指标混在一起
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	dbDuration   *prometheus.HistogramVec
+	authEvents   *prometheus.CounterVec
+}
+
+// New 创建并注册全部指标
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Database query latency by operation, table and result.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table", "result"}),
+		authEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "auth_events_total",
+			Help:      "Wallet authentication events. result is the login action on success or the error code on failure.",
+		}, []string{"event", "result"}),
+	}
+
+	build := buildinfo.Get()
+	buildInfo := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "build_info",
+		Help:      "Build information, always 1.",
+	}, []string{"version", "commit", "go_version"})
+	buildInfo.WithLabelValues(build.Version, build.Commit, build.GoVersion).Set(1)
+
+	m.registry.MustRegister(
+		m.httpRequests,
+		m.httpDuration,
+		m.dbDuration,
+		m.authEvents,
+		buildInfo,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Register 注册额外的采集器
+func (m *Metrics) Register(c prometheus.Collector) {
+	m.registry.MustRegister(c)
+}
+
+// RegisterGaugeFunc 注册抓取时求值的gauge
+func (m *Metrics) RegisterGaugeFunc(name, help string, fn func() float64) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// Handler 以Prometheus文本格式输出指标
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
+
+// Middleware 按路由模板统计请求数和延迟，需注册在路由之前
+func (m *Metrics) Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		route := c.Route().Path
+		if err != nil {
+			// 错误尚未经过ErrorHandler，按其映射规则取状态码
+			status = apperrors.From(err).Status
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusNotFound {
+				route = unmatchedRoute
+			}
+		}
+
+		labels := []string{c.Method(), route, strconv.Itoa(status)}
+		m.httpRequests.WithLabelValues(labels...).Inc()
+		m.httpDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// ChallengeIssued 记录签发的登录挑战
+func (m *Metrics) ChallengeIssued() {
+	m.authEvents.WithLabelValues("challenge", "issued").Inc()
+}
+
+// AuthSucceeded 记录签名验证成功，action 为 login、register 或 restore
+func (m *Metrics) AuthSucceeded(action string) {
+	m.authEvents.WithLabelValues("verify", action).Inc()
+}
+
+// AuthFailed 记录签名验证失败，reason 为错误码
+func (m *Metrics) AuthFailed(reason string) {
+	m.authEvents.WithLabelValues("verify_failed", reason).Inc()
+}
@@ -1,9 +1,16 @@
 package middleware
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"strings"
 
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/reqctx"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
 	"github.com/gofiber/fiber/v3"
 )
@@ -14,7 +21,20 @@ const UserIDKey AuthContextKey = "user_id"
 const UserRoleKey AuthContextKey = "role"
 const UsernameKey AuthContextKey = "username"
 
-func AuthMiddleware(cfg *config.Config) fiber.Handler {
+// IssuedTokenKey 处理器在本次请求中签发的token
+const IssuedTokenKey AuthContextKey = "issued_token"
+
+// AuthCookieName 存放token的cookie名称
+const AuthCookieName = "auth_token"
+
+// UserResolver 根据token中的用户ID获取用户的最新状态
+type UserResolver interface {
+	GetAuthUser(ctx context.Context, id uint) (*models.User, error)
+}
+
+// AuthMiddleware 校验token并从users重新加载用户，角色变更和删除即时生效。
+// users为nil时仅信任token中的声明
+func AuthMiddleware(cfg *config.Config, users UserResolver) fiber.Handler {
 	jwtUtil := utils.NewJWTUtil(cfg)
 	
 	return func(c fiber.Ctx) error {
@@ -22,33 +42,55 @@ func AuthMiddleware(cfg *config.Config) fiber.Handler {
 		if strings.Contains(c.Path(), "/auth/web3") {
 			return c.Next()
 		}
-		// 从cookie中获取token
-		token := c.Cookies("auth_token")
+		token := extractToken(c)
 		if token == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"message": "Authentication required",
-			})
+			return apperrors.ErrAuthRequired
 		}
 
 		// 验证token
 		claims, err := jwtUtil.VerifyToken(token)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"success": false,
-				"message": "Invalid or expired token",
-			})
+			return apperrors.ErrInvalidToken
+		}
+
+		role, username := claims.Role, claims.Username
+		if users != nil {
+			// 以数据库中的用户为准，已删除的用户直接拒绝
+			user, err := users.GetAuthUser(reqctx.From(c), claims.UserID)
+			if err != nil {
+				if errors.Is(err, apperrors.ErrUserNotFound) {
+					return apperrors.ErrUserGone
+				}
+				return apperrors.Internal(err)
+			}
+			role, username = string(user.Role), user.Username
 		}
 
 		// 将用户信息存储到context中
 		c.Locals(string(UserIDKey), claims.UserID)
-		c.Locals(string(UserRoleKey), claims.Role)
-		c.Locals(string(UsernameKey), claims.Username)
+		c.Locals(string(UserRoleKey), role)
+		c.Locals(string(UsernameKey), username)
+		// 之后的请求日志都带上用户ID
+		ctx := reqctx.From(c)
+		reqctx.Set(c, logger.NewContext(ctx, logger.FromContext(ctx).With("user_id", claims.UserID)))
 
 		return c.Next()
 	}
 }
 
+// extractToken 优先从cookie获取token，其次是 Authorization: Bearer 头
+func extractToken(c fiber.Ctx) string {
+	if token := c.Cookies(AuthCookieName); token != "" {
+		return token
+	}
+
+	header := c.Get(fiber.HeaderAuthorization)
+	if token, ok := strings.CutPrefix(header, "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
 func GetUserID(c fiber.Ctx) (uint, bool) {
 	userID, ok := c.Locals(string(UserIDKey)).(uint)
 	return userID, ok
@@ -62,4 +104,36 @@ func GetUserRole(c fiber.Ctx) (string, bool) {
 func GetUsername(c fiber.Ctx) (string, bool) {
 	username, ok := c.Locals(string(UsernameKey)).(string)
 	return username, ok
-}
\ No newline at end of file
+}
+// RequireRole 只允许指定角色访问，需在AuthMiddleware之后使用
+func RequireRole(roles ...models.UserRole) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		role, _ := GetUserRole(c)
+		for _, allowed := range roles {
+			if role == string(allowed) {
+				return c.Next()
+			}
+		}
+		return apperrors.ErrForbidden
+	}
+}
+
+// RequireBearerToken 校验固定的Bearer token，用于指标等内部接口；token为空时放行
+func RequireBearerToken(token string) fiber.Handler {
+	expected := []byte(token)
+	return func(c fiber.Ctx) error {
+		if token == "" {
+			return c.Next()
+		}
+
+		header := c.Get(fiber.HeaderAuthorization)
+		provided, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || provided == "" {
+			return apperrors.ErrAuthRequired
+		}
+		if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(provided)), expected) != 1 {
+			return apperrors.ErrInvalidToken
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// NodeCompat 将统一响应信封转换为Node.js版本的响应格式：
+// 成功时直接返回data，失败时返回NestJS的 {statusCode, message, error}
+func NodeCompat() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			// 先交给错误处理器生成信封，再统一转换
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+
+		if !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return nil
+		}
+
+		var envelope struct {
+			Success *bool           `json:"success"`
+			Data    json.RawMessage `json:"data"`
+			Message string          `json:"message"`
+		}
+		if err := json.Unmarshal(c.Response().Body(), &envelope); err != nil || envelope.Success == nil {
+			return nil
+		}
+
+		if !*envelope.Success {
+			status := c.Response().StatusCode()
+			return c.JSON(fiber.Map{
+				"statusCode": status,
+				"message":    envelope.Message,
+				"error":      http.StatusText(status),
+			})
+		}
+
+		body := []byte(envelope.Data)
+		if len(body) == 0 {
+			body = []byte("null")
+		}
+
+		// Node版本在登录响应中返回 bearer_token
+		if token, ok := c.Locals(string(IssuedTokenKey)).(string); ok && token != "" {
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(body, &object); err == nil {
+				object["bearer_token"], _ = json.Marshal(token)
+				body, _ = json.Marshal(object)
+			}
+		}
+
+		c.Response().SetBodyRaw(body)
+		return nil
+	}
+}
+
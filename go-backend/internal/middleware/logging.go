@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/utils/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/reqctx"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// RequestIDKey 当前请求的ID
+const RequestIDKey AuthContextKey = "request_id"
+
+// maxRequestIDLength 接受客户端传入的请求ID的最大长度
+const maxRequestIDLength = 128
+
+// RequestID 沿用客户端或网关传入的 X-Request-ID，没有或格式不合法时生成新的ID，
+// 并在响应头中返回
+func RequestID() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id := c.Get(fiber.HeaderXRequestID)
+		if !validRequestID(id) {
+			id = utils.UUIDv4()
+		} else {
+			// fasthttp会复用请求缓冲区，ID会被日志等在请求之外持有
+			id = strings.Clone(id)
+		}
+
+		c.Set(fiber.HeaderXRequestID, id)
+		c.Locals(string(RequestIDKey), id)
+		trace.SpanFromContext(reqctx.From(c)).SetAttributes(attribute.String("http.request.id", id))
+		return c.Next()
+	}
+}
+
+// validRequestID 只接受长度有限的可打印ID，避免日志注入
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.:", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRequestID 返回当前请求的ID
+func GetRequestID(c fiber.Ctx) string {
+	id, _ := c.Locals(string(RequestIDKey)).(string)
+	return id
+}
+
+// AccessLog 为请求创建附带请求ID和trace信息的日志记录器并放入请求context，
+// 请求结束后写入访问日志。成功请求按 cfg.AccessSampleRatio 采样，失败和慢请求始终记录。
+// 需注册在 RequestID 之后
+func AccessLog(l *logger.Logger, cfg config.LogConfig) fiber.Handler {
+	slow := time.Duration(cfg.SlowRequestMs) * time.Millisecond
+	return func(c fiber.Ctx) error {
+		start := time.Now()
+		ctx := reqctx.From(c)
+		reqLogger := l.WithContext(ctx).With("request_id", GetRequestID(c))
+		reqctx.Set(c, logger.NewContext(ctx, reqLogger))
+
+		err := c.Next()
+
+		latency := time.Since(start)
+		status := c.Response().StatusCode()
+		var appErr *apperrors.Error
+		if err != nil {
+			// 错误尚未经过ErrorHandler，按其映射规则取状态码
+			appErr = apperrors.From(err)
+			status = appErr.Status
+		}
+		if status < fiber.StatusBadRequest && (slow <= 0 || latency < slow) && rand.Float64() >= cfg.AccessSampleRatio {
+			return err
+		}
+
+		// 未匹配路由的请求没有路由模板
+		route := c.Route().Path
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusNotFound {
+			route = ""
+		}
+
+		// 认证中间件会在请求context中为记录器附加用户ID
+		reqLogger = logger.FromContext(reqctx.From(c))
+		args := []any{
+			"method", c.Method(),
+			"route", route,
+			"path", c.Path(),
+			"status", status,
+			"latency_ms", float64(latency.Microseconds()) / 1000,
+			"bytes_in", len(c.Body()),
+			"ip", c.IP(),
+		}
+		if appErr != nil {
+			// 错误响应由ErrorHandler在之后写入，此时还没有响应体
+			args = append(args, "error_code", appErr.Code)
+		} else {
+			args = append(args, "bytes_out", len(c.Response().Body()))
+		}
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			reqLogger.Error("Request completed", args...)
+		case status >= fiber.StatusBadRequest || (slow > 0 && latency >= slow):
+			reqLogger.Warn("Request completed", args...)
+		default:
+			reqLogger.Info("Request completed", args...)
+		}
+		return err
+	}
+}
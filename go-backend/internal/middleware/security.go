@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+)
+
+// APIContentSecurityPolicy 接口响应的默认CSP，不允许加载任何资源或被嵌入页面。
+// 需要渲染页面的处理器（如文档页面）自行覆盖
+const APIContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+
+// SecurityHeaders 为所有响应设置安全相关的响应头，在处理器之前设置以便其覆盖。
+//
+// TLS 由网关终止，服务本身看到的总是HTTP，因此配置了 max-age 就发送HSTS；
+// 浏览器会忽略通过HTTP收到的HSTS，本地开发不受影响
+func SecurityHeaders(cfg config.SecurityConfig) fiber.Handler {
+	hsts := ""
+	if cfg.HSTSMaxAgeSeconds > 0 {
+		hsts = "max-age=" + strconv.Itoa(cfg.HSTSMaxAgeSeconds)
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(c fiber.Ctx) error {
+		c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+		c.Set(fiber.HeaderXFrameOptions, "DENY")
+		c.Set(fiber.HeaderReferrerPolicy, cfg.ReferrerPolicy)
+		c.Set(fiber.HeaderContentSecurityPolicy, APIContentSecurityPolicy)
+		if hsts != "" {
+			c.Set(fiber.HeaderStrictTransportSecurity, hsts)
+		}
+		return c.Next()
+	}
+}
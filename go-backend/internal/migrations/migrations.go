@@ -0,0 +1,263 @@
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// sqlFiles 每种数据库一个目录，目录名为gorm方言名，各目录中的迁移版本一一对应
+//
+//go:embed sql/postgres/*.sql sql/sqlite/*.sql
+var sqlFiles embed.FS
+
+// Migration 一个版本化的数据库迁移
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status 迁移的应用状态
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// schemaMigration schema_migrations 表记录
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator 执行嵌入二进制的SQL迁移
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// New 创建迁移器，加载db所用数据库的嵌入迁移文件
+func New(db *gorm.DB) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	if _, err := fs.Stat(sqlFiles, path.Join("sql", dialect)); err != nil {
+		return nil, fmt.Errorf("no migrations for database %q", dialect)
+	}
+	migrations, err := load(sqlFiles, path.Join("sql", dialect))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// load 读取 <version>_<name>.up.sql / .down.sql 文件对
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		filename := entry.Name()
+
+		var direction string
+		switch {
+		case strings.HasSuffix(filename, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(filename, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(filename, "."+direction+".sql")
+		versionPart, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration filename %q", filename)
+		}
+		version, err := strconv.ParseInt(versionPart, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q", filename)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, filename))
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration version %d has conflicting names %q and %q", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// ensureTable 创建 schema_migrations 表
+func (m *Migrator) ensureTable() error {
+	return m.db.AutoMigrate(&schemaMigration{})
+}
+
+// applied 返回已应用的迁移，按版本号索引
+func (m *Migrator) applied() (map[int64]schemaMigration, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var records []schemaMigration
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]schemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// Status 返回所有迁移的状态
+func (m *Migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Pending 返回尚未应用的迁移
+func (m *Migrator) Pending() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Up 按版本顺序应用所有未应用的迁移，每个迁移在独立事务中执行
+func (m *Migrator) Up() ([]Migration, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range pending {
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s up failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down 回滚最近应用的steps个迁移
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, errors.New("steps must be positive")
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s down failed: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// EnsureUpToDate 数据库结构落后于二进制时返回错误
+func (m *Migrator) EnsureUpToDate() error {
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind: %d pending migration(s), starting with %d_%s; run `migrate up`",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package migrations
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/database"
+)
+
+// dialects 提供迁移的数据库，目录名为gorm方言名
+var dialects = []string{"postgres", "sqlite"}
+
+func TestDialectsHaveSameVersions(t *testing.T) {
+	versions := make(map[string][]string, len(dialects))
+	for _, dialect := range dialects {
+		migrations, err := load(sqlFiles, "sql/"+dialect)
+		if err != nil {
+			t.Fatalf("load %s: %v", dialect, err)
+		}
+		if len(migrations) == 0 {
+			t.Fatalf("no %s migrations", dialect)
+		}
+		for _, m := range migrations {
+			versions[dialect] = append(versions[dialect], m.Name)
+			if want := int64(len(versions[dialect])); m.Version != want {
+				t.Errorf("%s: migration %d_%s, want version %d without gaps", dialect, m.Version, m.Name, want)
+			}
+		}
+	}
+
+	want := versions[dialects[0]]
+	for _, dialect := range dialects[1:] {
+		if !slices.Equal(versions[dialect], want) {
+			t.Errorf("%s migrations %v differ from %s %v", dialect, versions[dialect], dialects[0], want)
+		}
+	}
+}
+
+// testPostgresEnv 设置后回填测试同时在该Postgres上运行，测试会删除其中的表
+const testPostgresEnv = "TEST_DATABASE_URL"
+
+// openDialect 打开空的测试库，未配置Postgres时跳过
+func openDialect(t *testing.T, dialect string) *gorm.DB {
+	t.Helper()
+	if dialect == "sqlite" {
+		return openSQLite(t)
+	}
+	url := os.Getenv(testPostgresEnv)
+	if url == "" {
+		t.Skipf("%s not set", testPostgresEnv)
+	}
+	db, err := database.Open(config.DatabaseConfig{Driver: config.DatabaseDriverPostgres, URL: url, MaxOpenConns: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reset := func() {
+		m, err := New(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.Down(len(m.migrations)); err != nil {
+			t.Fatalf("reset schema: %v", err)
+		}
+	}
+	reset()
+	t.Cleanup(func() {
+		reset()
+		database.Close(db)
+	})
+	return db
+}
+
+// upTo 应用到指定版本为止
+func upTo(t *testing.T, m *Migrator, version int64) {
+	t.Helper()
+	partial := &Migrator{db: m.db}
+	for _, migration := range m.migrations {
+		if migration.Version <= version {
+			partial.migrations = append(partial.migrations, migration)
+		}
+	}
+	if _, err := partial.Up(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestBackfillParity 在已有数据的库上升级，两种数据库回填的数据必须一致
+func TestBackfillParity(t *testing.T) {
+	type rewardRow struct {
+		UserID  uint
+		Address string
+		Status  string
+	}
+	type handleRow struct {
+		UserID       uint
+		GithubHandle *string
+	}
+	handle := func(s string) *string { return &s }
+	wantRewards := []rewardRow{{1, "0xaaa", "active"}, {4, "0xddd", "active"}}
+	wantHandles := []handleRow{{1, handle("alice-new")}, {2, nil}, {3, nil}, {4, nil}}
+
+	for _, dialect := range dialects {
+		t.Run(dialect, func(t *testing.T) {
+			db := openDialect(t, dialect)
+			m, err := New(db)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exec := func(sql string, args ...any) {
+				t.Helper()
+				if err := db.Exec(sql, args...).Error; err != nil {
+					t.Fatalf("%s: %v", sql, err)
+				}
+			}
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			// 0005 之前已有收款地址
+			upTo(t, m, 4)
+			exec(`INSERT INTO users (user_id, username, reward_address, created_at, updated_at) VALUES
+				(1, 'alice', '0xaaa', ?, ?), (2, 'bob', '', ?, ?), (3, 'carol', NULL, ?, ?), (4, 'dave', '0xddd', ?, ?)`,
+				now, now, now, now, now, now, now, now)
+
+			// 0007 之前已有审核过的开发者申请
+			upTo(t, m, 6)
+			insertApplication := func(userID uint, githubHandle, status string, reviewedAt *time.Time) {
+				exec(`INSERT INTO role_applications (user_id, requested_role, github_handle, status, reviewed_at, created_at, updated_at)
+					VALUES (?, 'developer', ?, ?, ?, ?, ?)`, userID, githubHandle, status, reviewedAt, now, now)
+			}
+			earlier, later := now.Add(time.Hour), now.Add(2*time.Hour)
+			insertApplication(1, "alice-old", "approved", &earlier)
+			insertApplication(1, "alice-new", "approved", &later)
+			insertApplication(2, "", "approved", &later)
+			insertApplication(3, "carol", "rejected", &later)
+			insertApplication(4, "dave", "pending", nil)
+
+			if _, err := m.Up(); err != nil {
+				t.Fatal(err)
+			}
+
+			var rewards []rewardRow
+			if err := db.Raw("SELECT user_id, address, status FROM reward_address_changes ORDER BY user_id").Scan(&rewards).Error; err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(rewards, wantRewards) {
+				t.Errorf("reward address history = %+v, want %+v", rewards, wantRewards)
+			}
+			// 回填的时间需要能被读取为 time.Time
+			var activated []struct{ EffectiveAt, ActivatedAt time.Time }
+			if err := db.Raw("SELECT effective_at, activated_at FROM reward_address_changes").Scan(&activated).Error; err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range activated {
+				if row.EffectiveAt.IsZero() || row.ActivatedAt.IsZero() {
+					t.Errorf("backfilled change without timestamps: %+v", row)
+				}
+			}
+
+			var handles []handleRow
+			if err := db.Raw("SELECT user_id, github_handle FROM users ORDER BY user_id").Scan(&handles).Error; err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(handles, wantHandles, func(a, b handleRow) bool {
+				return a.UserID == b.UserID && (a.GithubHandle == nil) == (b.GithubHandle == nil) &&
+					(a.GithubHandle == nil || *a.GithubHandle == *b.GithubHandle)
+			}) {
+				t.Errorf("github handles = %v, want %v", handles, wantHandles)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0002_second.up.sql":   {Data: []byte("up 2")},
+		"sql/0002_second.down.sql": {Data: []byte("down 2")},
+		"sql/0001_first.up.sql":    {Data: []byte("up 1")},
+		"sql/0001_first.down.sql":  {Data: []byte("down 1")},
+		"sql/README.md":            {Data: []byte("ignored")},
+	}
+	migrations, err := load(fsys, "sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Migration{
+		{Version: 1, Name: "first", Up: "up 1", Down: "down 1"},
+		{Version: 2, Name: "second", Up: "up 2", Down: "down 2"},
+	}
+	if !slices.Equal(migrations, want) {
+		t.Errorf("load = %+v, want %+v", migrations, want)
+	}
+}
+
+func TestLoadRejectsInvalidFiles(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{"missing down", []string{"0001_first.up.sql"}, "must have both up and down"},
+		{"missing version", []string{"first.up.sql", "first.down.sql"}, "invalid migration filename"},
+		{"bad version", []string{"v1_first.up.sql", "v1_first.down.sql"}, "invalid migration version"},
+		{"conflicting names", []string{"0001_first.up.sql", "0001_other.down.sql"}, "conflicting names"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for _, name := range tt.files {
+				fsys["sql/"+name] = &fstest.MapFile{Data: []byte("SELECT 1")}
+			}
+			if _, err := load(fsys, "sql"); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("load error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.Open(config.DatabaseConfig{Driver: config.DatabaseDriverSQLite, Path: ":memory:"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close(db) })
+	return db
+}
+
+func TestMigratorSQLite(t *testing.T) {
+	db := openSQLite(t)
+	m, err := New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := len(m.migrations)
+
+	if err := m.EnsureUpToDate(); err == nil {
+		t.Error("empty database reported up to date")
+	}
+
+	done, err := m.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != total {
+		t.Fatalf("applied %d migrations, want %d", len(done), total)
+	}
+	if err := m.EnsureUpToDate(); err != nil {
+		t.Errorf("after up: %v", err)
+	}
+
+	// 重复执行不会再次应用
+	if done, err := m.Up(); err != nil || len(done) != 0 {
+		t.Fatalf("second up applied %d migrations: %v", len(done), err)
+	}
+	var recorded int64
+	if err := db.Model(&schemaMigration{}).Count(&recorded).Error; err != nil || recorded != int64(total) {
+		t.Fatalf("schema_migrations has %d rows (%v), want %d", recorded, err, total)
+	}
+
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("migration %d_%s not marked applied", s.Version, s.Name)
+		}
+	}
+
+	// 回滚最近两个版本后只有这两个待应用
+	rolledBack, err := m.Down(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rolledBack) != 2 || rolledBack[0].Version != int64(total) || rolledBack[1].Version != int64(total-1) {
+		t.Fatalf("rolled back %+v", rolledBack)
+	}
+	pending, err := m.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].Version != int64(total-1) {
+		t.Fatalf("pending = %+v", pending)
+	}
+	if err := m.EnsureUpToDate(); err == nil || !strings.Contains(err.Error(), "2 pending") {
+		t.Errorf("EnsureUpToDate = %v", err)
+	}
+	if done, err := m.Up(); err != nil || len(done) != 2 {
+		t.Fatalf("re-apply: %d migrations, %v", len(done), err)
+	}
+
+	// 全部回滚后数据表被删除，可以重新迁移
+	if done, err := m.Down(total + 1); err != nil || len(done) != total {
+		t.Fatalf("down all: %d migrations, %v", len(done), err)
+	}
+	if db.Migrator().HasTable("users") {
+		t.Error("users table still exists after rolling back every migration")
+	}
+	if done, err := m.Up(); err != nil || len(done) != total {
+		t.Fatalf("up after down: %d migrations, %v", len(done), err)
+	}
+
+	if _, err := m.Down(0); err == nil {
+		t.Error("Down(0) should fail")
+	}
+}
+
+func TestMigratorRollsBackFailedMigration(t *testing.T) {
+	db := openSQLite(t)
+	m := &Migrator{db: db, migrations: []Migration{
+		{Version: 1, Name: "ok", Up: "CREATE TABLE ok (id INTEGER)", Down: "DROP TABLE ok"},
+		{Version: 2, Name: "broken", Up: "CREATE TABLE broken (id INTEGER); SELECT * FROM missing", Down: "DROP TABLE broken"},
+	}}
+
+	done, err := m.Up()
+	if err == nil || !strings.Contains(err.Error(), "2_broken") {
+		t.Fatalf("Up error = %v", err)
+	}
+	if len(done) != 1 {
+		t.Errorf("applied %d migrations before the failure, want 1", len(done))
+	}
+	pending, err := m.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].Version != 2 {
+		t.Errorf("pending = %+v, want only the failed migration", pending)
+	}
+	if db.Migrator().HasTable("broken") {
+		t.Error("failed migration was not rolled back")
+	}
+}
+
+func TestLoadMissingDialect(t *testing.T) {
+	if _, err := load(sqlFiles, "sql/mysql"); err == nil {
+		t.Error("expected an error for a dialect without migrations")
+	}
+}
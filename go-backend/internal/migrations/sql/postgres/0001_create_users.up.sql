@@ -0,0 +1,19 @@
+-- 用户及认证方法基础表。使用 IF NOT EXISTS 以接管此前由 AutoMigrate 创建的数据库
+CREATE TABLE IF NOT EXISTS users (
+    user_id        BIGSERIAL PRIMARY KEY,
+    username       TEXT NOT NULL,
+    email          TEXT,
+    role           VARCHAR(20) DEFAULT 'user',
+    reward_address TEXT,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS auth_methods (
+    auth_id         BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT NOT NULL,
+    auth_type       VARCHAR(20) NOT NULL,
+    auth_identifier TEXT NOT NULL,
+    created_at      TIMESTAMPTZ,
+    CONSTRAINT fk_users_auth_methods FOREIGN KEY (user_id) REFERENCES users (user_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_auth_methods_type_identifier;
+DROP INDEX IF EXISTS idx_users_username;
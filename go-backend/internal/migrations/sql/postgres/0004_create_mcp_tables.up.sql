@@ -0,0 +1,26 @@
+CREATE TABLE mcp_cards (
+    id           BIGSERIAL PRIMARY KEY,
+    name         TEXT,
+    tags         JSONB,
+    github_url   TEXT NOT NULL,
+    author       TEXT,
+    description  TEXT,
+    overview     TEXT,
+    tools        TEXT,
+    price        NUMERIC(10, 2),
+    configs      JSONB,
+    docker_image TEXT,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ
+);
+
+CREATE TABLE mcp_servers (
+    id         BIGSERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    image      TEXT NOT NULL,
+    status     JSONB,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_mcp_servers_name ON mcp_servers (name);
@@ -0,0 +1,23 @@
+CREATE TABLE reward_address_changes (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT NOT NULL REFERENCES users (user_id),
+    address      TEXT NOT NULL,
+    status       VARCHAR(20) NOT NULL,
+    effective_at TIMESTAMPTZ NOT NULL,
+    activated_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ
+);
+
+CREATE INDEX idx_reward_address_changes_user_id ON reward_address_changes (user_id);
+CREATE INDEX idx_reward_address_changes_status_effective_at ON reward_address_changes (status, effective_at);
+
+-- 每个用户同时最多只有一个待生效的变更
+CREATE UNIQUE INDEX idx_reward_address_changes_one_pending ON reward_address_changes (user_id) WHERE status = 'pending';
+
+-- 已有的收款地址作为历史的起点
+INSERT INTO reward_address_changes (user_id, address, status, effective_at, activated_at, created_at, updated_at)
+SELECT user_id, reward_address, 'active', NOW(), NOW(), NOW(), NOW()
+FROM users
+WHERE reward_address IS NOT NULL AND reward_address <> '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS role_application_events;
+DROP TABLE IF EXISTS role_applications;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_mcp_servers_owner_id;
+DROP INDEX IF EXISTS idx_mcp_cards_owner_id;
+
+ALTER TABLE mcp_servers DROP COLUMN IF EXISTS owner_id;
+ALTER TABLE mcp_cards DROP COLUMN IF EXISTS owner_id;
+
+ALTER TABLE users DROP COLUMN IF EXISTS github_handle;
+ALTER TABLE users DROP COLUMN IF EXISTS links;
+ALTER TABLE users DROP COLUMN IF EXISTS bio;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_url;
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
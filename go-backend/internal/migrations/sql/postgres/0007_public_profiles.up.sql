@@ -0,0 +1,21 @@
+ALTER TABLE users ADD COLUMN display_name TEXT;
+ALTER TABLE users ADD COLUMN avatar_url TEXT;
+ALTER TABLE users ADD COLUMN bio TEXT;
+ALTER TABLE users ADD COLUMN links JSONB;
+ALTER TABLE users ADD COLUMN github_handle TEXT;
+
+-- 沿用已通过的开发者申请中填写的GitHub用户名（未经验证）
+UPDATE users SET github_handle = approved.github_handle
+FROM (
+    SELECT DISTINCT ON (user_id) user_id, github_handle
+    FROM role_applications
+    WHERE status = 'approved' AND github_handle <> ''
+    ORDER BY user_id, reviewed_at DESC
+) AS approved
+WHERE users.user_id = approved.user_id;
+
+ALTER TABLE mcp_cards ADD COLUMN owner_id BIGINT REFERENCES users (user_id);
+ALTER TABLE mcp_servers ADD COLUMN owner_id BIGINT REFERENCES users (user_id);
+
+CREATE INDEX idx_mcp_cards_owner_id ON mcp_cards (owner_id);
+CREATE INDEX idx_mcp_servers_owner_id ON mcp_servers (owner_id);
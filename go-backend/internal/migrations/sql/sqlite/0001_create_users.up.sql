@@ -0,0 +1,20 @@
+-- 与 postgres 目录下的迁移一一对应，供本地开发和测试使用。SQLite 没有 BIGSERIAL、TIMESTAMPTZ 和 JSONB，
+-- 分别使用 INTEGER PRIMARY KEY AUTOINCREMENT、DATETIME 和 TEXT
+CREATE TABLE users (
+    user_id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    username       TEXT NOT NULL,
+    email          TEXT,
+    role           VARCHAR(20) DEFAULT 'user',
+    reward_address TEXT,
+    created_at     DATETIME,
+    updated_at     DATETIME
+);
+
+CREATE TABLE auth_methods (
+    auth_id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id         INTEGER NOT NULL,
+    auth_type       VARCHAR(20) NOT NULL,
+    auth_identifier TEXT NOT NULL,
+    created_at      DATETIME,
+    CONSTRAINT fk_users_auth_methods FOREIGN KEY (user_id) REFERENCES users (user_id)
+);
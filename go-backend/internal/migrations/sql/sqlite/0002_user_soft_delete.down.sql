@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_purged_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+ALTER TABLE users DROP COLUMN purged_at;
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN deleted_at DATETIME;
+ALTER TABLE users ADD COLUMN purged_at DATETIME;
+
+CREATE INDEX idx_users_deleted_at ON users (deleted_at);
+CREATE INDEX idx_users_purged_at ON users (purged_at);
@@ -0,0 +1,3 @@
+-- 已软删除的用户在清理前继续占用用户名，清理时用户名会被改写为 deleted-user-<id>
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_auth_methods_type_identifier ON auth_methods (auth_type, auth_identifier);
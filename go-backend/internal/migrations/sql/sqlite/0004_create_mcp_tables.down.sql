@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mcp_servers;
+DROP TABLE IF EXISTS mcp_cards;
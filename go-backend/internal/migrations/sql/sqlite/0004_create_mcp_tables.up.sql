@@ -0,0 +1,26 @@
+CREATE TABLE mcp_cards (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    name         TEXT,
+    tags         TEXT,
+    github_url   TEXT NOT NULL,
+    author       TEXT,
+    description  TEXT,
+    overview     TEXT,
+    tools        TEXT,
+    price        NUMERIC(10, 2),
+    configs      TEXT,
+    docker_image TEXT,
+    created_at   DATETIME,
+    updated_at   DATETIME
+);
+
+CREATE TABLE mcp_servers (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    name       TEXT NOT NULL,
+    image      TEXT NOT NULL,
+    status     TEXT,
+    created_at DATETIME,
+    updated_at DATETIME
+);
+
+CREATE UNIQUE INDEX idx_mcp_servers_name ON mcp_servers (name);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reward_address_changes;
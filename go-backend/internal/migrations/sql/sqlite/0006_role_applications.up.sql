@@ -0,0 +1,32 @@
+CREATE TABLE role_applications (
+    id               INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id          INTEGER NOT NULL REFERENCES users (user_id),
+    requested_role   VARCHAR(20) NOT NULL,
+    display_name     TEXT,
+    bio              TEXT,
+    github_handle    TEXT NOT NULL,
+    intended_servers TEXT,
+    status           VARCHAR(20) NOT NULL,
+    reviewer_id      INTEGER REFERENCES users (user_id),
+    review_notes     TEXT,
+    reviewed_at      DATETIME,
+    created_at       DATETIME,
+    updated_at       DATETIME
+);
+
+CREATE INDEX idx_role_applications_user_id ON role_applications (user_id);
+CREATE INDEX idx_role_applications_status ON role_applications (status, created_at);
+
+-- 每个用户同时最多只有一个待审核的申请
+CREATE UNIQUE INDEX idx_role_applications_one_pending ON role_applications (user_id) WHERE status = 'pending';
+
+CREATE TABLE role_application_events (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    application_id INTEGER NOT NULL REFERENCES role_applications (id),
+    actor_id       INTEGER NOT NULL REFERENCES users (user_id),
+    action         VARCHAR(20) NOT NULL,
+    notes          TEXT,
+    created_at     DATETIME
+);
+
+CREATE INDEX idx_role_application_events_application_id ON role_application_events (application_id);
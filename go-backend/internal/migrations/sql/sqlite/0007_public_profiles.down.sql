@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_mcp_servers_owner_id;
+DROP INDEX IF EXISTS idx_mcp_cards_owner_id;
+
+ALTER TABLE mcp_servers DROP COLUMN owner_id;
+ALTER TABLE mcp_cards DROP COLUMN owner_id;
+
+ALTER TABLE users DROP COLUMN github_handle;
+ALTER TABLE users DROP COLUMN links;
+ALTER TABLE users DROP COLUMN bio;
+ALTER TABLE users DROP COLUMN avatar_url;
+ALTER TABLE users DROP COLUMN display_name;
@@ -0,0 +1,24 @@
+ALTER TABLE users ADD COLUMN display_name TEXT;
+ALTER TABLE users ADD COLUMN avatar_url TEXT;
+ALTER TABLE users ADD COLUMN bio TEXT;
+ALTER TABLE users ADD COLUMN links TEXT;
+ALTER TABLE users ADD COLUMN github_handle TEXT;
+
+-- 沿用已通过的开发者申请中填写的GitHub用户名（未经验证）。
+-- SQLite 没有 DISTINCT ON，用子查询取每个用户最近审核的申请，空值排序与 Postgres 的 DESC 一致
+UPDATE users SET github_handle = (
+    SELECT github_handle
+    FROM role_applications
+    WHERE role_applications.user_id = users.user_id AND status = 'approved' AND github_handle <> ''
+    ORDER BY reviewed_at DESC NULLS FIRST
+    LIMIT 1
+)
+WHERE user_id IN (
+    SELECT user_id FROM role_applications WHERE status = 'approved' AND github_handle <> ''
+);
+
+ALTER TABLE mcp_cards ADD COLUMN owner_id INTEGER REFERENCES users (user_id);
+ALTER TABLE mcp_servers ADD COLUMN owner_id INTEGER REFERENCES users (user_id);
+
+CREATE INDEX idx_mcp_cards_owner_id ON mcp_cards (owner_id);
+CREATE INDEX idx_mcp_servers_owner_id ON mcp_servers (owner_id);
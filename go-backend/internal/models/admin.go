@@ -0,0 +1,11 @@
+package models
+
+// LogLevelRequest 运行时调整日志级别的请求
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error"`
+}
+
+// LogLevelResponse 当前日志级别
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
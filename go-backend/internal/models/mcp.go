@@ -0,0 +1,120 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// MCPCard MCP市场卡片
+type MCPCard struct {
+	ID          uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name        *string         `json:"name,omitempty"`
+	Tags        []string        `json:"tags,omitempty" gorm:"serializer:json"`
+	GithubURL   string          `json:"github_url" gorm:"column:github_url;not null"`
+	Author      *string         `json:"author,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Overview    *string         `json:"overview,omitempty"`
+	Tools       *string         `json:"tools,omitempty"`
+	Price       *string         `json:"price,omitempty" gorm:"type:numeric(10,2)"`
+	Configs     json.RawMessage `json:"configs,omitempty" gorm:"serializer:json"`
+	DockerImage *string         `json:"docker_image,omitempty"`
+	// OwnerID 发布卡片的开发者
+	OwnerID   *uint     `json:"owner_id,omitempty" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (MCPCard) TableName() string {
+	return "mcp_cards"
+}
+
+// MCPServer 已部署的MCP服务器
+type MCPServer struct {
+	ID     uint            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name   string          `json:"name" gorm:"not null;uniqueIndex:idx_mcp_servers_name"`
+	Image  string          `json:"image" gorm:"not null"`
+	Status json.RawMessage `json:"status,omitempty" gorm:"serializer:json"`
+	// OwnerID 发布服务器的开发者
+	OwnerID   *uint     `json:"owner_id,omitempty" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (MCPServer) TableName() string {
+	return "mcp_servers"
+}
+
+// Node.js版本直接返回Kubernetes中的MCPServer资源
+const (
+	MCPServerAPIVersion = "toolhive.stacklok.dev/v1alpha1"
+	MCPServerKind       = "MCPServer"
+)
+
+// MCPServerResource Kubernetes MCPServer资源格式，只包含数据库中记录的字段
+type MCPServerResource struct {
+	APIVersion string                    `json:"apiVersion"`
+	Kind       string                    `json:"kind"`
+	Metadata   MCPServerResourceMetadata `json:"metadata"`
+	Spec       MCPServerResourceSpec     `json:"spec"`
+	Status     json.RawMessage           `json:"status,omitempty"`
+}
+
+// MCPServerResourceMetadata 资源元数据，labels.user 为发布者ID
+type MCPServerResourceMetadata struct {
+	Name              string            `json:"name"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+	Labels            map[string]string `json:"labels,omitempty"`
+}
+
+// MCPServerResourceSpec 资源规格
+type MCPServerResourceSpec struct {
+	Image string `json:"image"`
+}
+
+// MCPServerResourceList Kubernetes资源列表格式
+type MCPServerResourceList struct {
+	APIVersion string              `json:"apiVersion"`
+	Kind       string              `json:"kind"`
+	Items      []MCPServerResource `json:"items"`
+}
+
+// NewMCPServerResource 将数据库记录转换为Kubernetes资源格式
+func NewMCPServerResource(server *MCPServer) MCPServerResource {
+	resource := MCPServerResource{
+		APIVersion: MCPServerAPIVersion,
+		Kind:       MCPServerKind,
+		Metadata: MCPServerResourceMetadata{
+			Name:              server.Name,
+			CreationTimestamp: server.CreatedAt.UTC(),
+		},
+		Spec:   MCPServerResourceSpec{Image: server.Image},
+		Status: server.Status,
+	}
+	if server.OwnerID != nil {
+		resource.Metadata.Labels = map[string]string{"user": strconv.FormatUint(uint64(*server.OwnerID), 10)}
+	}
+	return resource
+}
+
+// NewMCPServerResourceList 将数据库记录转换为Kubernetes资源列表格式
+func NewMCPServerResourceList(servers []MCPServer) MCPServerResourceList {
+	list := MCPServerResourceList{
+		APIVersion: MCPServerAPIVersion,
+		Kind:       MCPServerKind + "List",
+		Items:      make([]MCPServerResource, 0, len(servers)),
+	}
+	for i := range servers {
+		list.Items = append(list.Items, NewMCPServerResource(&servers[i]))
+	}
+	return list
+}
+
+// PlatformStats 平台业务统计，用于监控指标
+type PlatformStats struct {
+	UsersByRole             map[UserRole]int64
+	DeployedServers         int64
+	PublishedCards          int64
+	PendingRoleApplications int64
+	PendingRewardChanges    int64
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+)
+
+// ProfileLink 个人资料中的外部链接
+type ProfileLink struct {
+	Label string `json:"label" validate:"required,max=50"`
+	URL   string `json:"url" validate:"required,url"`
+}
+
+// PublicProfile 开发者公开资料，不包含邮箱、认证方式、收款地址等个人信息
+type PublicProfile struct {
+	Username    string        `json:"username"`
+	DisplayName *string       `json:"display_name,omitempty"`
+	AvatarURL   *string       `json:"avatar_url,omitempty"`
+	Bio         *string       `json:"bio,omitempty"`
+	Links       []ProfileLink `json:"links"`
+	// GithubHandle 用户自行填写、未经验证的GitHub用户名，展示时需标明
+	GithubHandle         *string           `json:"github_handle,omitempty"`
+	GithubHandleVerified bool              `json:"github_handle_verified"`
+	Role                 UserRole          `json:"role"`
+	JoinedAt             time.Time         `json:"joined_at"`
+	Servers              []PublicMCPServer `json:"servers"`
+	Cards                []PublicMCPCard   `json:"cards"`
+}
+
+// PublicMCPServer 公开资料中展示的MCP服务器
+type PublicMCPServer struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Image     string    `json:"image"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PublicMCPCard 公开资料中展示的MCP卡片
+type PublicMCPCard struct {
+	ID          uint      `json:"id"`
+	Name        *string   `json:"name,omitempty"`
+	Tags        []string  `json:"tags"`
+	GithubURL   string    `json:"github_url"`
+	Description *string   `json:"description,omitempty"`
+	DockerImage *string   `json:"docker_image,omitempty"`
+	Price       *string   `json:"price,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewPublicProfile 从账户信息构建公开资料
+func NewPublicProfile(user *User, servers []MCPServer, cards []MCPCard) *PublicProfile {
+	profile := &PublicProfile{
+		Username:     user.Username,
+		DisplayName:  user.DisplayName,
+		AvatarURL:    user.AvatarURL,
+		Bio:          user.Bio,
+		Links:        user.Links,
+		GithubHandle: user.GithubHandle,
+		// 尚未接入GitHub OAuth，用户名都未经验证
+		GithubHandleVerified: false,
+		Role:                 user.Role,
+		JoinedAt:             user.CreatedAt,
+		Servers:              make([]PublicMCPServer, 0, len(servers)),
+		Cards:                make([]PublicMCPCard, 0, len(cards)),
+	}
+	if profile.Links == nil {
+		profile.Links = []ProfileLink{}
+	}
+
+	for _, server := range servers {
+		profile.Servers = append(profile.Servers, PublicMCPServer{
+			ID:        server.ID,
+			Name:      server.Name,
+			Image:     server.Image,
+			CreatedAt: server.CreatedAt,
+		})
+	}
+	for _, card := range cards {
+		tags := card.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		profile.Cards = append(profile.Cards, PublicMCPCard{
+			ID:          card.ID,
+			Name:        card.Name,
+			Tags:        tags,
+			GithubURL:   card.GithubURL,
+			Description: card.Description,
+			DockerImage: card.DockerImage,
+			Price:       card.Price,
+			CreatedAt:   card.CreatedAt,
+		})
+	}
+
+	return profile
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// RewardAddressStatus 收款地址变更状态
+type RewardAddressStatus string
+
+const (
+	// RewardAddressPending 已验证所有权，等待冷静期结束
+	RewardAddressPending RewardAddressStatus = "pending"
+	// RewardAddressActive 当前生效的收款地址
+	RewardAddressActive RewardAddressStatus = "active"
+	// RewardAddressSuperseded 已被新地址取代
+	RewardAddressSuperseded RewardAddressStatus = "superseded"
+	// RewardAddressCancelled 冷静期内被取消
+	RewardAddressCancelled RewardAddressStatus = "cancelled"
+)
+
+// RewardAddressChange 收款地址变更记录，同时作为地址历史
+type RewardAddressChange struct {
+	ID          uint                `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID      uint                `json:"user_id" gorm:"not null;index"`
+	Address     string              `json:"address" gorm:"not null"`
+	Status      RewardAddressStatus `json:"status" gorm:"type:varchar(20);not null"`
+	EffectiveAt time.Time           `json:"effective_at" gorm:"not null"`
+	ActivatedAt *time.Time          `json:"activated_at,omitempty"`
+	CancelledAt *time.Time          `json:"cancelled_at,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+func (RewardAddressChange) TableName() string {
+	return "reward_address_changes"
+}
+
+// RewardAddressChallengeRequest 收款地址所有权挑战请求DTO
+type RewardAddressChallengeRequest struct {
+	Address string `json:"address" validate:"required,eth_address"`
+}
+
+// RewardAddressChangeRequest 收款地址变更请求DTO，签名由新地址对挑战nonce签署
+type RewardAddressChangeRequest struct {
+	Address   string `json:"address" validate:"required,eth_address"`
+	Signature string `json:"signature" validate:"required"`
+	Nonce     string `json:"nonce" validate:"required"`
+}
+
+// RewardAddressOverview 收款地址概览
+type RewardAddressOverview struct {
+	Current *string               `json:"current"`
+	Pending *RewardAddressChange  `json:"pending,omitempty"`
+	History []RewardAddressChange `json:"history"`
+}
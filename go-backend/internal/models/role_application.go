@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+)
+
+// RoleApplicationStatus 角色申请状态
+type RoleApplicationStatus string
+
+const (
+	RoleApplicationPending  RoleApplicationStatus = "pending"
+	RoleApplicationApproved RoleApplicationStatus = "approved"
+	RoleApplicationRejected RoleApplicationStatus = "rejected"
+)
+
+// RoleApplicationAction 角色申请审计事件类型
+type RoleApplicationAction string
+
+const (
+	RoleApplicationSubmitted RoleApplicationAction = "submitted"
+	RoleApplicationApprove   RoleApplicationAction = "approved"
+	RoleApplicationReject    RoleApplicationAction = "rejected"
+	// RoleApplicationProvisioned 运维命令直接设置角色，没有申请和审核过程
+	RoleApplicationProvisioned RoleApplicationAction = "provisioned"
+)
+
+// RoleApplication 开发者角色申请，审核通过后才变更用户角色
+type RoleApplication struct {
+	ID              uint                   `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID          uint                   `json:"user_id" gorm:"not null;index"`
+	RequestedRole   UserRole               `json:"requested_role" gorm:"type:varchar(20);not null"`
+	DisplayName     *string                `json:"display_name,omitempty"`
+	Bio             *string                `json:"bio,omitempty"`
+	GithubHandle    string                 `json:"github_handle" gorm:"not null"`
+	IntendedServers []string               `json:"intended_servers" gorm:"serializer:json"`
+	Status          RoleApplicationStatus  `json:"status" gorm:"type:varchar(20);not null"`
+	ReviewerID      *uint                  `json:"reviewer_id,omitempty"`
+	ReviewNotes     *string                `json:"review_notes,omitempty"`
+	ReviewedAt      *time.Time             `json:"reviewed_at,omitempty"`
+	Events          []RoleApplicationEvent `json:"events,omitempty" gorm:"foreignKey:ApplicationID"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+func (RoleApplication) TableName() string {
+	return "role_applications"
+}
+
+// RoleApplicationEvent 角色申请的审计记录，只追加不修改
+type RoleApplicationEvent struct {
+	ID            uint                  `json:"id" gorm:"primaryKey;autoIncrement"`
+	ApplicationID uint                  `json:"application_id" gorm:"not null;index"`
+	ActorID       uint                  `json:"actor_id" gorm:"not null"`
+	Action        RoleApplicationAction `json:"action" gorm:"type:varchar(20);not null"`
+	Notes         *string               `json:"notes,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+func (RoleApplicationEvent) TableName() string {
+	return "role_application_events"
+}
+
+// RoleApplicationRequest 提交开发者申请DTO
+type RoleApplicationRequest struct {
+	DisplayName     *string  `json:"display_name,omitempty" validate:"omitempty,max=100"`
+	Bio             *string  `json:"bio,omitempty" validate:"omitempty,max=1000"`
+	GithubHandle    string   `json:"github_handle" validate:"required,max=40"`
+	IntendedServers []string `json:"intended_servers" validate:"required,max=20"`
+}
+
+// RoleApplicationReviewRequest 审核开发者申请DTO
+type RoleApplicationReviewRequest struct {
+	Notes *string `json:"notes,omitempty" validate:"omitempty,max=2000"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// 以下响应与Node.js版本 /auth 接口的格式一致，仅供兼容层使用
+
+// SessionUser 当前会话的用户
+type SessionUser struct {
+	UserID   uint     `json:"userId"`
+	Username string   `json:"username"`
+	Role     UserRole `json:"role"`
+}
+
+// SessionResponse 当前会话 GET /auth/me
+type SessionResponse struct {
+	Success bool        `json:"success"`
+	User    SessionUser `json:"user"`
+	Message string      `json:"message"`
+}
+
+// BearerTokenResponse 为命令行等非浏览器客户端签发的令牌 GET /auth/bearer-token
+type BearerTokenResponse struct {
+	Success     bool   `json:"success"`
+	BearerToken string `json:"bearer_token"`
+	ExpiresIn   string `json:"expires_in"`
+	Message     string `json:"message"`
+}
+
+// AuthStatusResponse 认证服务状态 GET /auth/status
+type AuthStatusResponse struct {
+	Success   bool      `json:"success"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/buildinfo"
+)
+
+// ServiceStatus 服务状态，不检查依赖
+type ServiceStatus struct {
+	Status        string         `json:"status"` // "healthy" or "shutting_down"
+	Timestamp     int64          `json:"timestamp"`
+	Build         buildinfo.Info `json:"build"`
+	Environment   string         `json:"environment"`
+	StartedAt     int64          `json:"started_at"`
+	UptimeSeconds int64          `json:"uptime_seconds"`
+}
+
+// MessageResponse 只包含提示信息的响应
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// DeleteUserResponse 删除账户的响应，宽限期结束前可通过钱包登录恢复
+type DeleteUserResponse struct {
+	Message      string `json:"message"`
+	RestoreUntil int64  `json:"restore_until"`
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type UserRole string
@@ -9,17 +11,40 @@ type UserRole string
 const (
 	UserRoleUser      UserRole = "user"
 	UserRoleDeveloper UserRole = "developer"
+	// UserRoleAdmin 审核开发者申请等管理操作
+	UserRoleAdmin UserRole = "admin"
 )
 
+// IsValid 检查角色是否受支持
+func (r UserRole) IsValid() bool {
+	switch r {
+	case UserRoleUser, UserRoleDeveloper, UserRoleAdmin:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	UserID        uint         `json:"user_id" gorm:"primaryKey;autoIncrement"`
-	Username      string       `json:"username" gorm:"not null"`
-	Email         *string      `json:"email,omitempty"`
-	Role          UserRole     `json:"role" gorm:"type:varchar(20);default:'user'"`
-	RewardAddress *string      `json:"reward_address,omitempty"`
-	AuthMethods   []AuthMethod `json:"auth_methods,omitempty" gorm:"foreignKey:UserID"`
-	CreatedAt     time.Time    `json:"created_at"`
-	UpdatedAt     time.Time    `json:"updated_at"`
+	UserID        uint     `json:"user_id" gorm:"primaryKey;autoIncrement"`
+	Username      string   `json:"username" gorm:"not null;uniqueIndex:idx_users_username"`
+	Email         *string  `json:"email,omitempty"`
+	Role          UserRole `json:"role" gorm:"type:varchar(20);default:'user'"`
+	RewardAddress *string  `json:"reward_address,omitempty"`
+	// 公开资料字段，通过 PublicProfile 对外展示
+	DisplayName *string       `json:"display_name,omitempty"`
+	AvatarURL   *string       `json:"avatar_url,omitempty"`
+	Bio         *string       `json:"bio,omitempty"`
+	Links       []ProfileLink `json:"links,omitempty" gorm:"serializer:json"`
+	// GithubHandle 开发者申请中自行填写的GitHub用户名，审核通过时写入。
+	// 未经GitHub验证，不能作为身份证明
+	GithubHandle *string      `json:"github_handle,omitempty"`
+	AuthMethods  []AuthMethod `json:"auth_methods,omitempty" gorm:"foreignKey:UserID"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	// DeletedAt 软删除时间，宽限期内可恢复
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	// PurgedAt 宽限期结束后数据被匿名化的时间
+	PurgedAt *time.Time `json:"-" gorm:"index"`
 }
 
 type AuthType string
@@ -30,11 +55,20 @@ const (
 	AuthTypeGitHub AuthType = "github"
 )
 
+// IsValid 检查认证方式是否受支持
+func (t AuthType) IsValid() bool {
+	switch t {
+	case AuthTypeWeb3, AuthTypeGoogle, AuthTypeGitHub:
+		return true
+	}
+	return false
+}
+
 type AuthMethod struct {
 	AuthID         uint      `json:"auth_id" gorm:"primaryKey;autoIncrement"`
 	UserID         uint      `json:"user_id" gorm:"not null"`
-	AuthType       AuthType  `json:"auth_type" gorm:"type:varchar(20);not null"`
-	AuthIdentifier string    `json:"auth_identifier" gorm:"not null"`
+	AuthType       AuthType  `json:"auth_type" gorm:"type:varchar(20);not null;uniqueIndex:idx_auth_methods_type_identifier"`
+	AuthIdentifier string    `json:"auth_identifier" gorm:"not null;uniqueIndex:idx_auth_methods_type_identifier"`
 	User           User      `json:"-" gorm:"foreignKey:UserID"`
 	CreatedAt      time.Time `json:"created_at"`
 }
@@ -46,3 +80,14 @@ func (AuthMethod) TableName() string {
 func (User) TableName() string {
 	return "users"
 }
+
+// UserDataExport 用户个人数据导出
+type UserDataExport struct {
+	ExportedAt           time.Time             `json:"exported_at"`
+	Profile              User                  `json:"profile"`
+	AuthMethods          []AuthMethod          `json:"auth_methods"`
+	RewardAddressHistory []RewardAddressChange `json:"reward_address_history"`
+	RoleApplications     []RoleApplication     `json:"role_applications"`
+	MCPServers           []MCPServer           `json:"mcp_servers"`
+	MCPCards             []MCPCard             `json:"mcp_cards"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+)
+
+// SortOrder 排序方向
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// UserSortField 用户列表可排序字段
+type UserSortField string
+
+const (
+	UserSortByID        UserSortField = "user_id"
+	UserSortByUsername  UserSortField = "username"
+	UserSortByCreatedAt UserSortField = "created_at"
+	UserSortByUpdatedAt UserSortField = "updated_at"
+)
+
+// IsValid 检查排序字段是否受支持
+func (f UserSortField) IsValid() bool {
+	switch f {
+	case UserSortByID, UserSortByUsername, UserSortByCreatedAt, UserSortByUpdatedAt:
+		return true
+	}
+	return false
+}
+
+// UserListQuery 用户列表查询条件
+type UserListQuery struct {
+	Role           *UserRole
+	AuthType       *AuthType
+	AuthIdentifier *string // 与AuthType配合使用，对应Node版本的 GET /user/by-auth
+	CreatedAfter   *time.Time
+	CreatedBefore  *time.Time
+	UsernamePrefix *string
+	EmailPrefix    *string
+
+	SortBy UserSortField
+	Order  SortOrder
+
+	// Cursor 不为空时使用游标分页并忽略Offset
+	Cursor string
+	Limit  int
+	Offset int
+}
+
+// Pagination 分页信息
+type Pagination struct {
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// UserPage 分页的用户列表
+type UserPage struct {
+	Users      []User
+	Pagination Pagination
+}
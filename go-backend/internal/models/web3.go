@@ -6,7 +6,7 @@ import (
 
 // Web3ChallengeRequest 挑战请求DTO
 type Web3ChallengeRequest struct {
-	Address string `json:"address" binding:"required" validate:"required"`
+	Address string `json:"address" query:"address" validate:"required,eth_address"`
 }
 
 // Web3ChallengeResponse 挑战响应DTO
@@ -17,19 +17,19 @@ type Web3ChallengeResponse struct {
 
 // Web3AuthRequest 认证请求DTO
 type Web3AuthRequest struct {
-	Address       string    `json:"address" binding:"required" validate:"required"`
-	Signature     string    `json:"signature" binding:"required" validate:"required"`
-	Nonce         string    `json:"nonce" binding:"required" validate:"required"`
-	Username      *string   `json:"username,omitempty"`
-	Email         *string   `json:"email,omitempty"`
-	Role          *UserRole `json:"role,omitempty"`
-	RewardAddress *string   `json:"reward_address,omitempty"`
+	Address   string  `json:"address" validate:"required,eth_address"`
+	Signature string  `json:"signature" validate:"required"`
+	Nonce     string  `json:"nonce" validate:"required"`
+	Username  *string `json:"username,omitempty" validate:"omitempty,min=3,max=50"`
+	Email     *string `json:"email,omitempty" validate:"omitempty,email"`
+	// Restore 恢复处于删除宽限期内的账户
+	Restore bool `json:"restore,omitempty"`
 }
 
 // Web3AuthResponse 认证响应DTO
 type Web3AuthResponse struct {
 	Success bool   `json:"success"`
-	Action  string `json:"action"` // "login" or "register"
+	Action  string `json:"action"` // "login", "register" or "restore"
 	User    User   `json:"user"`
 	Message string `json:"message"`
 }
@@ -42,18 +42,21 @@ type NonceStore struct {
 
 // CreateUserRequest 创建用户请求DTO
 type CreateUserRequest struct {
-	Username       string    `json:"username" binding:"required" validate:"required"`
-	Email          *string   `json:"email,omitempty"`
-	Role           *UserRole `json:"role,omitempty"`
-	RewardAddress  *string   `json:"reward_address,omitempty"`
-	AuthType       AuthType  `json:"auth_type" binding:"required" validate:"required"`
-	AuthIdentifier string    `json:"auth_identifier" binding:"required" validate:"required"`
+	Username       string   `json:"username" validate:"required,min=3,max=50"`
+	Email          *string  `json:"email,omitempty" validate:"omitempty,email"`
+	AuthType       AuthType `json:"auth_type" validate:"required,enum"`
+	AuthIdentifier string   `json:"auth_identifier" validate:"required,max=255"`
 }
 
 // UpdateUserRequest 更新用户请求DTO
 type UpdateUserRequest struct {
-	Username      *string   `json:"username,omitempty"`
-	Email         *string   `json:"email,omitempty"`
+	Username    *string       `json:"username,omitempty" validate:"min=3,max=50"`
+	Email       *string       `json:"email,omitempty" validate:"omitempty,email"`
+	DisplayName *string       `json:"display_name,omitempty" validate:"omitempty,max=100"`
+	AvatarURL   *string       `json:"avatar_url,omitempty" validate:"omitempty,url"`
+	Bio         *string       `json:"bio,omitempty" validate:"omitempty,max=1000"`
+	Links       []ProfileLink `json:"links,omitempty" validate:"omitempty,max=10"`
+	// Role 和 RewardAddress 不能直接修改，仅用于返回明确的错误
 	Role          *UserRole `json:"role,omitempty"`
 	RewardAddress *string   `json:"reward_address,omitempty"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,259 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+)
+
+// Auth 接口的认证要求
+type Auth int
+
+const (
+	// AuthNone 无需登录
+	AuthNone Auth = iota
+	// AuthUser 需要登录
+	AuthUser
+	// AuthAdmin 需要管理员角色
+	AuthAdmin
+)
+
+// Endpoint 单个接口的文档信息，Path 使用fiber的路由写法，如 /api/v1/user/:id
+type Endpoint struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Tag         string
+	Auth        Auth
+	// Query 带query标签的查询参数结构体
+	Query any
+	// Params 由处理器手动解析的查询参数
+	Params []Parameter
+	// Body 请求体类型
+	Body any
+	// BodyOptional 请求体可以省略
+	BodyOptional bool
+	// Response 成功响应中 data 字段的类型，为nil时 data 省略
+	Response any
+	// Pagination 分页信息的类型，不为nil时响应附带 pagination 字段
+	Pagination any
+	// Raw 响应直接返回 Response，不使用统一的响应格式
+	Raw bool
+}
+
+// key 方法和规范化后的路径
+func (e Endpoint) key() string {
+	return e.Method + " " + normalizePath(e.Path)
+}
+
+// Build 根据路由表和接口文档生成OpenAPI文档。
+// 返回的drift列出已注册但没有文档、或有文档但未注册的路由，为空表示两者一致
+func Build(info Info, routes []fiber.Route, endpoints []Endpoint, enums []Enum) (*Document, []string) {
+	g := newSchemaGenerator(enums)
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      make(map[string]PathItem),
+		Components: Components{Schemas: g.schemas},
+	}
+
+	registered := make(map[string]bool)
+	for _, route := range routes {
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		registered[route.Method+" "+normalizePath(route.Path)] = true
+	}
+
+	var drift []string
+	documented := make(map[string]bool)
+	tags := make(map[string]bool)
+	for _, e := range endpoints {
+		key := e.key()
+		if documented[key] {
+			panic(fmt.Sprintf("openapi: endpoint %s documented twice", key))
+		}
+		documented[key] = true
+		if !registered[key] {
+			drift = append(drift, "documented but not registered: "+key)
+			continue
+		}
+
+		path := normalizePath(e.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(PathItem)
+		}
+		doc.Paths[path][strings.ToLower(e.Method)] = g.operation(e)
+		if e.Tag != "" && !tags[e.Tag] {
+			tags[e.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: e.Tag})
+		}
+	}
+	for key := range registered {
+		if !documented[key] {
+			drift = append(drift, "registered but not documented: "+key)
+		}
+	}
+	sort.Strings(drift)
+
+	doc.Components.Responses = g.errorResponses()
+	doc.Components.SecuritySchemes = map[string]SecurityScheme{
+		"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		"cookieAuth": {Type: "apiKey", In: "cookie", Name: "auth_token"}, // middleware.AuthCookieName
+	}
+	return doc, drift
+}
+
+// normalizePath 去掉末尾斜杠并将 :id 转换为 {id}
+func normalizePath(path string) string {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = "{" + strings.TrimSuffix(s[1:], "?") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// operation 生成单个接口的文档
+func (g *schemaGenerator) operation(e Endpoint) *Operation {
+	op := &Operation{
+		OperationID: e.OperationID,
+		Summary:     e.Summary,
+		Responses:   make(map[string]*Response),
+	}
+	if e.Tag != "" {
+		op.Tags = []string{e.Tag}
+	}
+
+	for _, s := range strings.Split(normalizePath(e.Path), "/") {
+		if !strings.HasPrefix(s, "{") {
+			continue
+		}
+		name := strings.Trim(s, "{}")
+		schema := &Schema{Type: "string"}
+		if name == "id" {
+			schema = &Schema{Type: "integer", Format: "int32"}
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	if e.Query != nil {
+		op.Parameters = append(op.Parameters, g.queryParameters(reflect.TypeOf(e.Query))...)
+	}
+	op.Parameters = append(op.Parameters, e.Params...)
+
+	if e.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: !e.BodyOptional,
+			Content:  jsonContent(g.schemaFor(reflect.TypeOf(e.Body))),
+		}
+	}
+
+	op.Responses["200"] = g.successResponse(e)
+	if e.Body != nil || len(op.Parameters) > 0 {
+		op.Responses["400"] = &Response{Ref: "#/components/responses/BadRequest"}
+	}
+	switch e.Auth {
+	case AuthAdmin:
+		op.Responses["403"] = &Response{Ref: "#/components/responses/Forbidden"}
+		fallthrough
+	case AuthUser:
+		op.Responses["401"] = &Response{Ref: "#/components/responses/Unauthorized"}
+		op.Security = []map[string][]string{{"bearerAuth": {}}, {"cookieAuth": {}}}
+	}
+	if strings.Contains(e.Path, ":") {
+		op.Responses["404"] = &Response{Ref: "#/components/responses/NotFound"}
+	}
+	op.Responses["default"] = &Response{Ref: "#/components/responses/Error"}
+	return op
+}
+
+// queryParameters 按query标签生成查询参数
+func (g *schemaGenerator) queryParameters(t reflect.Type) []Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var params []Parameter
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("query"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema, required := g.fieldSchema(field, true)
+		params = append(params, Parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return params
+}
+
+// successResponse 成功响应，默认使用 {success, data, timestamp} 格式
+func (g *schemaGenerator) successResponse(e Endpoint) *Response {
+	resp := &Response{Description: "OK"}
+	if e.Raw {
+		if e.Response != nil {
+			resp.Content = jsonContent(g.schemaFor(reflect.TypeOf(e.Response)))
+		}
+		return resp
+	}
+
+	envelope := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"success":   {Type: "boolean"},
+			"timestamp": {Type: "integer", Format: "int64"},
+		},
+		Required: []string{"success", "timestamp"},
+	}
+	if e.Response != nil {
+		envelope.Properties["data"] = g.schemaFor(reflect.TypeOf(e.Response))
+		envelope.Required = append(envelope.Required, "data")
+	}
+	if e.Pagination != nil {
+		envelope.Properties["pagination"] = g.schemaFor(reflect.TypeOf(e.Pagination))
+		envelope.Required = append(envelope.Required, "pagination")
+	}
+	resp.Content = jsonContent(envelope)
+	return resp
+}
+
+// errorResponses 统一的错误响应，对应 app 错误处理器写出的格式
+func (g *schemaGenerator) errorResponses() map[string]*Response {
+	g.schemas["ErrorResponse"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"success":   {Type: "boolean", Enum: []any{false}},
+			"code":      {Type: "string"},
+			"message":   {Type: "string"},
+			"timestamp": {Type: "integer", Format: "int64"},
+			"details":   g.schemaFor(reflect.TypeFor[[]apperrors.FieldError]()),
+		},
+		Required: []string{"success", "code", "message", "timestamp"},
+	}
+	errorContent := jsonContent(&Schema{Ref: "#/components/schemas/ErrorResponse"})
+
+	responses := make(map[string]*Response)
+	for name, status := range map[string]int{
+		"BadRequest":   http.StatusBadRequest,
+		"Unauthorized": http.StatusUnauthorized,
+		"Forbidden":    http.StatusForbidden,
+		"NotFound":     http.StatusNotFound,
+	} {
+		responses[name] = &Response{Description: http.StatusText(status), Content: errorContent}
+	}
+	responses["Error"] = &Response{Description: "Error", Content: errorContent}
+	return responses
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{fiber.MIMEApplicationJSON: {Schema: schema}}
+}
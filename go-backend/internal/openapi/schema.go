@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ethAddressPattern 与校验规则 eth_address 对应的格式
+const ethAddressPattern = "^0x[0-9a-fA-F]{40}$"
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// Enum 字符串枚举类型的取值，生成文档时以 enum 列出
+type Enum struct {
+	typ    reflect.Type
+	values []any
+}
+
+// EnumOf 声明枚举类型T的全部取值
+func EnumOf[T ~string](values ...T) Enum {
+	e := Enum{typ: reflect.TypeFor[T]()}
+	for _, v := range values {
+		e.values = append(e.values, string(v))
+	}
+	return e
+}
+
+// schemaGenerator 将Go类型转换为Schema，具名结构体放入 components.schemas 并以 $ref 引用
+type schemaGenerator struct {
+	enums   map[reflect.Type][]any
+	schemas map[string]*Schema
+	// types 记录组件名对应的类型，不同包的同名类型视为冲突
+	types map[string]reflect.Type
+}
+
+func newSchemaGenerator(enums []Enum) *schemaGenerator {
+	g := &schemaGenerator{
+		enums:   make(map[reflect.Type][]any, len(enums)),
+		schemas: make(map[string]*Schema),
+		types:   make(map[string]reflect.Type),
+	}
+	for _, e := range enums {
+		g.enums[e.typ] = e.values
+	}
+	return g
+}
+
+// schemaFor 返回类型对应的Schema
+func (g *schemaGenerator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if values, ok := g.enums[t]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		// 任意JSON
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.ref(t)
+	case reflect.Interface:
+		return &Schema{}
+	}
+	panic(fmt.Sprintf("openapi: unsupported type %s", t))
+}
+
+// ref 注册具名结构体并返回引用
+func (g *schemaGenerator) ref(t reflect.Type) *Schema {
+	name := t.Name()
+	if existing, ok := g.types[name]; ok {
+		if existing != t {
+			panic(fmt.Sprintf("openapi: schema name %s used by both %s and %s", name, existing, t))
+		}
+	} else {
+		g.types[name] = t
+		// 先占位，避免自引用的类型无限递归
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// structSchema 按json标签生成对象结构，validate标签转换为约束
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		// 匿名嵌入的结构体字段展开到外层
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded := g.structSchema(field.Type)
+			for k, v := range embedded.Properties {
+				s.Properties[k] = v
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop, required := g.fieldSchema(field, strings.Contains(opts, "omitempty"))
+		s.Properties[name] = prop
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// fieldSchema 返回字段的Schema以及是否必填。
+// 有 validate 标签时以 required 规则为准；没有时非指针且不带 omitempty 的字段总会出现在JSON中，视为必填
+func (g *schemaGenerator) fieldSchema(field reflect.StructField, omitempty bool) (*Schema, bool) {
+	s := g.schemaFor(field.Type)
+	rules := parseRules(field.Tag.Get("validate"))
+	isPointer := field.Type.Kind() == reflect.Pointer
+
+	if isPointer && !omitempty && s.Ref == "" {
+		s.Nullable = true
+	}
+	if s.Ref == "" {
+		applyRules(s, rules)
+	}
+
+	if len(rules) > 0 {
+		_, required := rules["required"]
+		return s, required
+	}
+	return s, !omitempty && !isPointer
+}
+
+// applyRules 将校验规则转换为Schema约束，$ref 旁不能附加约束
+func applyRules(s *Schema, rules map[string]string) {
+	for rule, param := range rules {
+		switch rule {
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			switch {
+			case s.Type == "string" && rule == "min":
+				s.MinLength = &n
+			case s.Type == "string":
+				s.MaxLength = &n
+			case s.Type == "array" && rule == "min":
+				s.MinItems = &n
+			case s.Type == "array":
+				s.MaxItems = &n
+			}
+		case "email":
+			s.Format = "email"
+		case "url":
+			s.Format = "uri"
+		case "eth_address":
+			s.Pattern = ethAddressPattern
+		case "oneof":
+			s.Enum = nil
+			for _, v := range strings.Fields(param) {
+				s.Enum = append(s.Enum, v)
+			}
+		}
+	}
+}
+
+// parseRules 解析 "required,min=3,max=50" 形式的标签
+func parseRules(tag string) map[string]string {
+	rules := make(map[string]string)
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			rules[name] = param
+		}
+	}
+	return rules
+}
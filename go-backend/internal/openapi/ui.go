@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"html"
+)
+
+// swaggerUIVersion 文档页面使用的 swagger-ui-dist 主版本
+const swaggerUIVersion = "5"
+
+// swaggerUIOrigin 加载 Swagger UI 静态资源的CDN
+const swaggerUIOrigin = "https://unpkg.com"
+
+// DocsHTML 返回加载指定文档地址的 Swagger UI 页面
+func DocsHTML(title, specURL string) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>%[1]s</title>
+  <link rel="stylesheet" href="%[2]s/swagger-ui-dist@%[3]s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="%[2]s/swagger-ui-dist@%[3]s/swagger-ui-bundle.js" crossorigin></script>
+  <script>%[4]s</script>
+</body>
+</html>
+`, html.EscapeString(title), swaggerUIOrigin, swaggerUIVersion, docsScript(specURL))
+}
+
+// DocsCSP 返回文档页面的CSP：脚本只允许CDN和按哈希放行的内联初始化脚本，
+// Swagger UI 会写入内联样式，因此样式允许 unsafe-inline
+func DocsCSP(specURL string) string {
+	sum := sha256.Sum256([]byte(docsScript(specURL)))
+	return fmt.Sprintf("default-src 'none'; script-src %s 'sha256-%s'; style-src %s 'unsafe-inline'; "+
+		"img-src 'self' data:; connect-src 'self'; base-uri 'none'; form-action 'none'; frame-ancestors 'none'",
+		swaggerUIOrigin, base64.StdEncoding.EncodeToString(sum[:]), swaggerUIOrigin)
+}
+
+// docsScript 初始化 Swagger UI 的内联脚本，DocsCSP 按其内容计算哈希
+func docsScript(specURL string) string {
+	return fmt.Sprintf(`
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui", withCredentials: true });
+    };
+  `, specURL)
+}
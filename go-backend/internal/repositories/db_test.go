@@ -0,0 +1,65 @@
+package repositories_test
+
+import (
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/database"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/migrations"
+)
+
+// testPostgresEnv 设置后仓储测试同时在该Postgres上运行。测试会清空并重建其中的表，不要指向有数据的库
+const testPostgresEnv = "TEST_DATABASE_URL"
+
+// forEachDatabase 在内存SQLite和（若配置了）Postgres上分别运行fn，每次都从空库开始
+func forEachDatabase(t *testing.T, fn func(t *testing.T, db *gorm.DB)) {
+	t.Helper()
+
+	configs := []config.DatabaseConfig{{Driver: config.DatabaseDriverSQLite, Path: ":memory:"}}
+	if url := os.Getenv(testPostgresEnv); url != "" {
+		configs = append(configs, config.DatabaseConfig{Driver: config.DatabaseDriverPostgres, URL: url, MaxOpenConns: 5})
+	}
+
+	for _, cfg := range configs {
+		t.Run(cfg.Driver, func(t *testing.T) {
+			fn(t, openTestDB(t, cfg))
+		})
+	}
+}
+
+// openTestDB 打开数据库并执行全部迁移，测试结束时回滚全部迁移，顺带检查down迁移可用
+func openTestDB(t *testing.T, cfg config.DatabaseConfig) *gorm.DB {
+	t.Helper()
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("open %s: %v", cfg.Driver, err)
+	}
+	migrator, err := migrations.New(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 上次中断的测试可能留下了表
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Down(len(statuses)); err != nil {
+		t.Fatalf("reset schema: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+
+	t.Cleanup(func() {
+		defer database.Close(db)
+		if _, err := migrator.Down(len(statuses)); err != nil {
+			t.Errorf("migrate down: %v", err)
+		}
+	})
+	return db
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// MCPRepository MCP服务器和卡片仓储接口
+type MCPRepository interface {
+	ListServers() ([]models.MCPServer, error)
+	FindServerByName(name string) (*models.MCPServer, error)
+	ListServersByOwner(ownerID uint) ([]models.MCPServer, error)
+	ListCards() ([]models.MCPCard, error)
+	FindCardByID(id uint) (*models.MCPCard, error)
+	ListCardsByOwner(ownerID uint) ([]models.MCPCard, error)
+}
+
+// mcpRepository GORM实现
+type mcpRepository struct {
+	db *gorm.DB
+}
+
+// NewMCPRepository 创建MCP仓储
+func NewMCPRepository(db *gorm.DB) MCPRepository {
+	return &mcpRepository{db: db}
+}
+
+// ListServers 列出所有MCP服务器
+func (r *mcpRepository) ListServers() ([]models.MCPServer, error) {
+	var servers []models.MCPServer
+	err := r.db.Order("created_at DESC, id DESC").Find(&servers).Error
+	return servers, err
+}
+
+// FindServerByName 根据名称查找MCP服务器，不存在时返回nil
+func (r *mcpRepository) FindServerByName(name string) (*models.MCPServer, error) {
+	var server models.MCPServer
+	err := r.db.Where("name = ?", name).First(&server).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+// ListServersByOwner 列出开发者发布的MCP服务器
+func (r *mcpRepository) ListServersByOwner(ownerID uint) ([]models.MCPServer, error) {
+	var servers []models.MCPServer
+	err := r.db.Where("owner_id = ?", ownerID).Order("created_at DESC, id DESC").Find(&servers).Error
+	return servers, err
+}
+
+// ListCards 列出所有MCP卡片
+func (r *mcpRepository) ListCards() ([]models.MCPCard, error) {
+	var cards []models.MCPCard
+	err := r.db.Order("created_at DESC, id DESC").Find(&cards).Error
+	return cards, err
+}
+
+// FindCardByID 根据ID查找MCP卡片，不存在时返回nil
+func (r *mcpRepository) FindCardByID(id uint) (*models.MCPCard, error) {
+	var card models.MCPCard
+	err := r.db.First(&card, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// ListCardsByOwner 列出开发者发布的MCP卡片
+func (r *mcpRepository) ListCardsByOwner(ownerID uint) ([]models.MCPCard, error) {
+	var cards []models.MCPCard
+	err := r.db.Where("owner_id = ?", ownerID).Order("created_at DESC, id DESC").Find(&cards).Error
+	return cards, err
+}
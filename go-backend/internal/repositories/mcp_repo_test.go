@@ -0,0 +1,60 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+)
+
+func TestMCPRepositoryReads(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewMCPRepository(db)
+		owner := createUser(t, repositories.NewUserRepository(db), "dev", models.UserRoleDeveloper, time.Time{})
+
+		servers := []models.MCPServer{
+			{Name: "old", Image: "example/old:1", CreatedAt: baseTime},
+			{Name: "new", Image: "example/new:1", OwnerID: &owner.UserID, CreatedAt: baseTime.Add(time.Hour)},
+		}
+		if err := db.Create(&servers).Error; err != nil {
+			t.Fatal(err)
+		}
+		name := "Weather"
+		card := models.MCPCard{Name: &name, GithubURL: "https://github.com/example/weather", Tags: []string{"weather"}, OwnerID: &owner.UserID}
+		if err := db.Create(&card).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		all, err := repo.ListServers()
+		if err != nil || len(all) != 2 || all[0].Name != "new" {
+			t.Fatalf("ListServers = %+v, %v", all, err)
+		}
+		owned, err := repo.ListServersByOwner(owner.UserID)
+		if err != nil || len(owned) != 1 || owned[0].Name != "new" {
+			t.Fatalf("ListServersByOwner = %+v, %v", owned, err)
+		}
+
+		found, err := repo.FindServerByName("old")
+		if err != nil || found == nil || found.Image != "example/old:1" {
+			t.Fatalf("FindServerByName = %+v, %v", found, err)
+		}
+		if missing, err := repo.FindServerByName("missing"); err != nil || missing != nil {
+			t.Errorf("FindServerByName(missing) = %+v, %v", missing, err)
+		}
+
+		cards, err := repo.ListCards()
+		if err != nil || len(cards) != 1 {
+			t.Fatalf("ListCards = %+v, %v", cards, err)
+		}
+		got, err := repo.FindCardByID(card.ID)
+		if err != nil || got == nil || *got.Name != name || len(got.Tags) != 1 {
+			t.Fatalf("FindCardByID = %+v, %v", got, err)
+		}
+		if missing, err := repo.FindCardByID(card.ID + 1); err != nil || missing != nil {
+			t.Errorf("FindCardByID(missing) = %+v, %v", missing, err)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// RewardAddressRepository 收款地址变更仓储接口
+type RewardAddressRepository interface {
+	// ReplacePending 取消用户已有的待生效变更并创建新的变更
+	ReplacePending(change *models.RewardAddressChange) error
+	FindPending(userID uint) (*models.RewardAddressChange, error)
+	ListByUser(userID uint) ([]models.RewardAddressChange, error)
+	CancelPending(userID uint) (*models.RewardAddressChange, error)
+	FindDue(now time.Time, limit int) ([]models.RewardAddressChange, error)
+	// Activate 使变更生效并同步 users.reward_address
+	Activate(change *models.RewardAddressChange) error
+}
+
+// rewardAddressRepository GORM实现
+type rewardAddressRepository struct {
+	db *gorm.DB
+}
+
+// NewRewardAddressRepository 创建收款地址变更仓储
+func NewRewardAddressRepository(db *gorm.DB) RewardAddressRepository {
+	return &rewardAddressRepository{db: db}
+}
+
+// ReplacePending 取消旧的待生效变更并创建新变更
+func (r *rewardAddressRepository) ReplacePending(change *models.RewardAddressChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.RewardAddressChange{}).
+			Where("user_id = ? AND status = ?", change.UserID, models.RewardAddressPending).
+			Updates(map[string]any{
+				"status":       models.RewardAddressCancelled,
+				"cancelled_at": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return translateError(tx.Create(change).Error)
+	})
+}
+
+// FindPending 查找用户待生效的变更
+func (r *rewardAddressRepository) FindPending(userID uint) (*models.RewardAddressChange, error) {
+	var change models.RewardAddressChange
+	err := r.db.Where("user_id = ? AND status = ?", userID, models.RewardAddressPending).First(&change).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ListByUser 按时间倒序列出用户的全部地址变更
+func (r *rewardAddressRepository) ListByUser(userID uint) ([]models.RewardAddressChange, error) {
+	var changes []models.RewardAddressChange
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&changes).Error
+	return changes, err
+}
+
+// CancelPending 取消用户待生效的变更，没有待生效变更时返回nil
+func (r *rewardAddressRepository) CancelPending(userID uint) (*models.RewardAddressChange, error) {
+	change, err := r.FindPending(userID)
+	if err != nil || change == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	change.Status = models.RewardAddressCancelled
+	change.CancelledAt = &now
+	if err := r.db.Save(change).Error; err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// FindDue 查找冷静期已结束的待生效变更
+func (r *rewardAddressRepository) FindDue(now time.Time, limit int) ([]models.RewardAddressChange, error) {
+	var changes []models.RewardAddressChange
+	err := r.db.Where("status = ? AND effective_at <= ?", models.RewardAddressPending, now).
+		Order("effective_at").
+		Limit(limit).
+		Find(&changes).Error
+	return changes, err
+}
+
+// Activate 在事务中取代旧地址、激活新地址并更新用户的收款地址
+func (r *rewardAddressRepository) Activate(change *models.RewardAddressChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.RewardAddressChange{}).
+			Where("user_id = ? AND status = ?", change.UserID, models.RewardAddressActive).
+			Update("status", models.RewardAddressSuperseded).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		change.Status = models.RewardAddressActive
+		change.ActivatedAt = &now
+		if err := tx.Save(change).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).
+			Where("user_id = ?", change.UserID).
+			Update("reward_address", change.Address).Error
+	})
+}
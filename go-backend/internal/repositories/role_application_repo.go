@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// RoleApplicationRepository 角色申请仓储接口
+type RoleApplicationRepository interface {
+	// Create 创建申请并记录提交事件
+	Create(application *models.RoleApplication, actorID uint) error
+	FindByID(id uint) (*models.RoleApplication, error)
+	FindPendingByUser(userID uint) (*models.RoleApplication, error)
+	ListByUser(userID uint) ([]models.RoleApplication, error)
+	List(status *models.RoleApplicationStatus) ([]models.RoleApplication, error)
+	// Review 在事务中记录审核结果和审计事件，通过时同时变更用户角色
+	Review(application *models.RoleApplication, event *models.RoleApplicationEvent) error
+	// Provision 在事务中记录已审核的申请和审计事件并变更用户角色
+	Provision(application *models.RoleApplication, event *models.RoleApplicationEvent) error
+}
+
+// roleApplicationRepository GORM实现
+type roleApplicationRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleApplicationRepository 创建角色申请仓储
+func NewRoleApplicationRepository(db *gorm.DB) RoleApplicationRepository {
+	return &roleApplicationRepository{db: db}
+}
+
+// Create 创建申请并记录提交事件
+func (r *roleApplicationRepository) Create(application *models.RoleApplication, actorID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(application).Error; err != nil {
+			return translateError(err)
+		}
+
+		return tx.Create(&models.RoleApplicationEvent{
+			ApplicationID: application.ID,
+			ActorID:       actorID,
+			Action:        models.RoleApplicationSubmitted,
+		}).Error
+	})
+}
+
+// FindByID 根据ID查找申请，包含审计事件
+func (r *roleApplicationRepository) FindByID(id uint) (*models.RoleApplication, error) {
+	var application models.RoleApplication
+	err := r.db.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	}).First(&application, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrRoleApplicationNotFound
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+// FindPendingByUser 查找用户待审核的申请
+func (r *roleApplicationRepository) FindPendingByUser(userID uint) (*models.RoleApplication, error) {
+	var application models.RoleApplication
+	err := r.db.Where("user_id = ? AND status = ?", userID, models.RoleApplicationPending).First(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+// ListByUser 按时间倒序列出用户的全部申请及审计事件
+func (r *roleApplicationRepository) ListByUser(userID uint) ([]models.RoleApplication, error) {
+	var applications []models.RoleApplication
+	err := r.db.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at, id")
+	}).Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&applications).Error
+	return applications, err
+}
+
+// List 按状态列出申请，最早提交的排在前面
+func (r *roleApplicationRepository) List(status *models.RoleApplicationStatus) ([]models.RoleApplication, error) {
+	tx := r.db.Order("created_at, id")
+	if status != nil {
+		tx = tx.Where("status = ?", *status)
+	}
+
+	var applications []models.RoleApplication
+	err := tx.Find(&applications).Error
+	return applications, err
+}
+
+// Review 只更新仍处于待审核状态的申请，防止并发审核
+func (r *roleApplicationRepository) Review(application *models.RoleApplication, event *models.RoleApplicationEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RoleApplication{}).
+			Where("id = ? AND status = ?", application.ID, models.RoleApplicationPending).
+			Updates(map[string]any{
+				"status":       application.Status,
+				"reviewer_id":  application.ReviewerID,
+				"review_notes": application.ReviewNotes,
+				"reviewed_at":  application.ReviewedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.ErrRoleApplicationReviewed
+		}
+
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+
+		if application.Status != models.RoleApplicationApproved {
+			return nil
+		}
+		// GitHub用户名是申请人自行填写的，公开资料中标记为未验证
+		return tx.Model(&models.User{}).
+			Where("user_id = ?", application.UserID).
+			Updates(map[string]any{
+				"role":          application.RequestedRole,
+				"github_handle": application.GithubHandle,
+			}).Error
+	})
+}
+
+// Provision 运维命令设置角色时同样留下申请记录和审计事件
+func (r *roleApplicationRepository) Provision(application *models.RoleApplication, event *models.RoleApplicationEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(application).Error; err != nil {
+			return translateError(err)
+		}
+
+		event.ApplicationID = application.ID
+		if err := tx.Create(event).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.User{}).
+			Where("user_id = ?", application.UserID).
+			Update("role", application.RequestedRole).Error
+	})
+}
@@ -0,0 +1,58 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+)
+
+func TestRoleApplicationRepositoryProvision(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		users := repositories.NewUserRepository(db)
+		repo := repositories.NewRoleApplicationRepository(db)
+		user := createUser(t, users, "ops", models.UserRoleUser, time.Time{})
+
+		notes := "role changed from user to admin by root@host via mcpforge create-admin"
+		application := &models.RoleApplication{
+			UserID:        user.UserID,
+			RequestedRole: models.UserRoleAdmin,
+			GithubHandle:  "ops",
+			Status:        models.RoleApplicationApproved,
+			ReviewNotes:   &notes,
+			ReviewedAt:    &baseTime,
+		}
+		event := &models.RoleApplicationEvent{ActorID: user.UserID, Action: models.RoleApplicationProvisioned, Notes: &notes}
+		if err := repo.Provision(application, event); err != nil {
+			t.Fatal(err)
+		}
+		if event.ApplicationID != application.ID {
+			t.Errorf("event application = %d, want %d", event.ApplicationID, application.ID)
+		}
+
+		updated, err := users.FindByID(user.UserID)
+		if err != nil || updated.Role != models.UserRoleAdmin {
+			t.Fatalf("user = %+v, %v", updated, err)
+		}
+
+		history, err := repo.ListByUser(user.UserID)
+		if err != nil || len(history) != 1 || len(history[0].Events) != 1 {
+			t.Fatalf("ListByUser = %+v, %v", history, err)
+		}
+		if got := history[0].Events[0]; got.Action != models.RoleApplicationProvisioned || got.Notes == nil || *got.Notes != notes {
+			t.Errorf("event = %+v", got)
+		}
+
+		// 审计事件写入失败时角色保持不变
+		failing := &models.RoleApplication{UserID: user.UserID, RequestedRole: models.UserRoleDeveloper, GithubHandle: "ops", Status: models.RoleApplicationApproved}
+		if err := repo.Provision(failing, &models.RoleApplicationEvent{ActorID: 999999, Action: models.RoleApplicationProvisioned}); err == nil {
+			t.Fatal("Provision with a missing actor succeeded")
+		}
+		if current, _ := users.FindByID(user.UserID); current.Role != models.UserRoleAdmin {
+			t.Errorf("role = %s after a failed provision", current.Role)
+		}
+	})
+}
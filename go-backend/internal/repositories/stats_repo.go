@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// StatsRepository 平台业务统计仓储接口
+type StatsRepository interface {
+	PlatformStats() (*models.PlatformStats, error)
+}
+
+// statsRepository GORM实现
+type statsRepository struct {
+	db *gorm.DB
+}
+
+// NewStatsRepository 创建业务统计仓储
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+// PlatformStats 统计用户、已部署服务器、已发布卡片和待处理事项的数量
+func (r *statsRepository) PlatformStats() (*models.PlatformStats, error) {
+	stats := &models.PlatformStats{UsersByRole: make(map[models.UserRole]int64)}
+
+	var roleCounts []struct {
+		Role  models.UserRole
+		Count int64
+	}
+	err := r.db.Model(&models.User{}).Select("role, COUNT(*) AS count").Group("role").Scan(&roleCounts).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, rc := range roleCounts {
+		stats.UsersByRole[rc.Role] = rc.Count
+	}
+
+	counts := []struct {
+		dest  *int64
+		query *gorm.DB
+	}{
+		{&stats.DeployedServers, r.db.Model(&models.MCPServer{})},
+		{&stats.PublishedCards, r.db.Model(&models.MCPCard{})},
+		{&stats.PendingRoleApplications, r.db.Model(&models.RoleApplication{}).Where("status = ?", models.RoleApplicationPending)},
+		{&stats.PendingRewardChanges, r.db.Model(&models.RewardAddressChange{}).Where("status = ?", models.RewardAddressPending)},
+	}
+	for _, c := range counts {
+		if err := c.query.Count(c.dest).Error; err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
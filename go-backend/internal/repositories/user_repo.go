@@ -1,22 +1,50 @@
 package repositories
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
 )
 
+// ErrDuplicateKey 违反唯一约束，通常由并发注册引起。仅在服务层内部处理，不直接返回给客户端
+var ErrDuplicateKey = errors.New("duplicate key")
+
+const (
+	// DefaultUserPageSize 未指定limit时的分页大小
+	DefaultUserPageSize = 20
+	// MaxUserPageSize 单页返回的最大用户数
+	MaxUserPageSize = 100
+)
+
 // UserRepository 用户仓储接口
 type UserRepository interface {
+	// WithTx 在事务中执行fn，fn返回错误时回滚。fn内必须使用传入的repo
+	WithTx(fn func(repo UserRepository) error) error
+	// WithContext 返回绑定ctx的仓储，查询随ctx取消并作为ctx中span的子span
+	WithContext(ctx context.Context) UserRepository
+
 	Create(user *models.User) error
 	FindByID(id uint) (*models.User, error)
-	FindAll() ([]models.User, error)
+	List(query *models.UserListQuery) (*models.UserPage, error)
 	Update(user *models.User) error
 	Delete(id uint) error
 	FindByAuthMethod(authType models.AuthType, authIdentifier string) (*models.User, error)
 	CreateAuthMethod(authMethod *models.AuthMethod) error
 	FindByUsername(username string) (*models.User, error)
+
+	// 软删除账户的生命周期
+	FindDeletedByAuthMethod(authType models.AuthType, authIdentifier string) (*models.User, error)
+	Restore(id uint) error
+	FindPurgeable(deletedBefore time.Time, limit int) ([]models.User, error)
+	Purge(id uint) error
 }
 
 // userRepository GORM实现
@@ -29,9 +57,21 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
+// WithTx 在事务中执行fn
+func (r *userRepository) WithTx(fn func(repo UserRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&userRepository{db: tx})
+	})
+}
+
+// WithContext 返回绑定ctx的仓储
+func (r *userRepository) WithContext(ctx context.Context) UserRepository {
+	return &userRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create 创建用户
 func (r *userRepository) Create(user *models.User) error {
-	return r.db.Create(user).Error
+	return translateError(r.db.Create(user).Error)
 }
 
 // FindByID 根据ID查找用户
@@ -40,26 +80,194 @@ func (r *userRepository) FindByID(id uint) (*models.User, error) {
 	err := r.db.Preload("AuthMethods").First(&user, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, apperrors.ErrUserNotFound
 		}
 		return nil, err
 	}
 	return &user, nil
 }
 
-// FindAll 查找所有用户
-func (r *userRepository) FindAll() ([]models.User, error) {
+// List 按条件分页查询用户，支持offset和游标两种分页方式
+func (r *userRepository) List(query *models.UserListQuery) (*models.UserPage, error) {
+	var total int64
+	if err := r.filterUsers(query).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultUserPageSize
+	}
+	if limit > MaxUserPageSize {
+		limit = MaxUserPageSize
+	}
+
+	sortBy := query.SortBy
+	if !sortBy.IsValid() {
+		sortBy = models.UserSortByID
+	}
+	order := models.SortAsc
+	if query.Order == models.SortDesc {
+		order = models.SortDesc
+	}
+
+	page := &models.UserPage{
+		Pagination: models.Pagination{Total: total, Limit: limit},
+	}
+
+	tx := r.filterUsers(query)
+	if query.Cursor != "" {
+		cursor, err := decodeUserCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		// 游标只对签发时的排序字段和方向有效，换用其他排序会比较不同类型的值
+		if cursor.Sort != sortBy || cursor.Order != order {
+			return nil, apperrors.Field("cursor", fmt.Sprintf("was issued for sort=%s order=%s; repeat the query with the same sort and order", cursor.Sort, cursor.Order))
+		}
+		tx, err = applyUserCursor(tx, sortBy, order, cursor)
+		if err != nil {
+			return nil, err
+		}
+	} else if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+		page.Pagination.Offset = query.Offset
+	}
+
+	// user_id 作为次级排序保证顺序稳定
+	tx = tx.Order(fmt.Sprintf("%s %s", sortBy, order))
+	if sortBy != models.UserSortByID {
+		tx = tx.Order(fmt.Sprintf("user_id %s", order))
+	}
+
+	// 多取一条用于判断是否还有下一页
 	var users []models.User
-	err := r.db.Preload("AuthMethods").Find(&users).Error
-	return users, err
+	if err := tx.Preload("AuthMethods").Limit(limit + 1).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	if len(users) > limit {
+		users = users[:limit]
+		page.Pagination.NextCursor = encodeUserCursor(sortBy, order, &users[len(users)-1])
+	}
+	page.Users = users
+
+	return page, nil
+}
+
+// filterUsers 根据查询条件构建过滤语句
+func (r *userRepository) filterUsers(query *models.UserListQuery) *gorm.DB {
+	tx := r.db.Model(&models.User{})
+
+	if query.Role != nil {
+		tx = tx.Where("role = ?", *query.Role)
+	}
+	if query.AuthType != nil || query.AuthIdentifier != nil {
+		sub := r.db.Model(&models.AuthMethod{}).Select("1").Where("auth_methods.user_id = users.user_id")
+		if query.AuthType != nil {
+			sub = sub.Where("auth_methods.auth_type = ?", *query.AuthType)
+		}
+		if query.AuthIdentifier != nil {
+			sub = sub.Where("auth_methods.auth_identifier = ?", *query.AuthIdentifier)
+		}
+		tx = tx.Where("EXISTS (?)", sub)
+	}
+	if query.CreatedAfter != nil {
+		tx = tx.Where("created_at >= ?", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		tx = tx.Where("created_at < ?", *query.CreatedBefore)
+	}
+	if query.UsernamePrefix != nil {
+		tx = tx.Where(`LOWER(username) LIKE ? ESCAPE '\'`, likePrefix(*query.UsernamePrefix))
+	}
+	if query.EmailPrefix != nil {
+		tx = tx.Where(`LOWER(email) LIKE ? ESCAPE '\'`, likePrefix(*query.EmailPrefix))
+	}
+
+	return tx
+}
+
+// userCursor 游标内容：签发时的排序方式，以及上一页最后一条记录的排序值和ID
+type userCursor struct {
+	Sort  models.UserSortField `json:"s"`
+	Order models.SortOrder     `json:"o"`
+	Value string               `json:"v,omitempty"`
+	ID    uint                 `json:"id"`
+}
+
+func encodeUserCursor(sortBy models.UserSortField, order models.SortOrder, user *models.User) string {
+	cursor := userCursor{Sort: sortBy, Order: order, ID: user.UserID}
+	switch sortBy {
+	case models.UserSortByUsername:
+		cursor.Value = user.Username
+	case models.UserSortByCreatedAt:
+		cursor.Value = user.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case models.UserSortByUpdatedAt:
+		cursor.Value = user.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeUserCursor(encoded string) (*userCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, apperrors.ErrInvalidCursor
+	}
+
+	var cursor userCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, apperrors.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// applyUserCursor 添加keyset分页条件，取排在游标之后的记录
+func applyUserCursor(tx *gorm.DB, sortBy models.UserSortField, order models.SortOrder, cursor *userCursor) (*gorm.DB, error) {
+	op := ">"
+	if order == models.SortDesc {
+		op = "<"
+	}
+
+	if sortBy == models.UserSortByID {
+		return tx.Where(fmt.Sprintf("user_id %s ?", op), cursor.ID), nil
+	}
+
+	var value any = cursor.Value
+	if sortBy == models.UserSortByCreatedAt || sortBy == models.UserSortByUpdatedAt {
+		t, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, apperrors.ErrInvalidCursor
+		}
+		value = t
+	}
+
+	condition := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND user_id %[2]s ?))", sortBy, op)
+	return tx.Where(condition, value, value, cursor.ID), nil
+}
+
+// translateError 将数据库唯一约束错误转换为ErrDuplicateKey，需开启gorm的TranslateError
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicateKey
+	}
+	return err
+}
+
+// likePrefix 转义LIKE通配符并构造前缀匹配模式
+func likePrefix(prefix string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(strings.ToLower(prefix)) + "%"
 }
 
 // Update 更新用户
 func (r *userRepository) Update(user *models.User) error {
-	return r.db.Save(user).Error
+	return translateError(r.db.Save(user).Error)
 }
 
-// Delete 删除用户
+// Delete 软删除用户，认证方法保留到清理时删除
 func (r *userRepository) Delete(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
@@ -78,18 +286,23 @@ func (r *userRepository) FindByAuthMethod(authType models.AuthType, authIdentifi
 		return nil, err
 	}
 
+	// 所属用户已被软删除
+	if authMethod.User.UserID == 0 {
+		return nil, nil
+	}
+
 	return &authMethod.User, nil
 }
 
 // CreateAuthMethod 创建认证方法
 func (r *userRepository) CreateAuthMethod(authMethod *models.AuthMethod) error {
-	return r.db.Create(authMethod).Error
+	return translateError(r.db.Create(authMethod).Error)
 }
 
-// FindByUsername 根据用户名查找用户
+// FindByUsername 根据用户名查找用户，包括已软删除的用户，宽限期内用户名仍被占用
 func (r *userRepository) FindByUsername(username string) (*models.User, error) {
 	var user models.User
-	err := r.db.Where("username = ?", username).First(&user).Error
+	err := r.db.Unscoped().Where("username = ?", username).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // 用户不存在返回nil而不是错误
@@ -98,3 +311,83 @@ func (r *userRepository) FindByUsername(username string) (*models.User, error) {
 	}
 	return &user, nil
 }
+
+// FindDeletedByAuthMethod 查找认证方法所属的已软删除且未清理的用户
+func (r *userRepository) FindDeletedByAuthMethod(authType models.AuthType, authIdentifier string) (*models.User, error) {
+	var user models.User
+	err := r.db.Unscoped().
+		Joins("JOIN auth_methods ON auth_methods.user_id = users.user_id").
+		Where("auth_methods.auth_type = ? AND auth_methods.auth_identifier = ?", authType, authIdentifier).
+		Where("users.deleted_at IS NOT NULL AND users.purged_at IS NULL").
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Restore 恢复已软删除且未清理的用户
+func (r *userRepository) Restore(id uint) error {
+	result := r.db.Unscoped().Model(&models.User{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL AND purged_at IS NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// FindPurgeable 查找删除时间早于deletedBefore且尚未清理的用户
+func (r *userRepository) FindPurgeable(deletedBefore time.Time, limit int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND purged_at IS NULL", deletedBefore).
+		Order("user_id").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+// Purge 删除用户的认证方法并匿名化个人数据，用户行保留以维持引用
+func (r *userRepository) Purge(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&models.AuthMethod{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.RewardAddressChange{}).Error; err != nil {
+			return err
+		}
+		// 申请的审核记录保留用于审计，只清除申请人填写的资料
+		err := tx.Model(&models.RoleApplication{}).
+			Where("user_id = ?", id).
+			Updates(map[string]any{
+				"display_name":     nil,
+				"bio":              nil,
+				"github_handle":    "",
+				"intended_servers": nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&models.User{}).
+			Where("user_id = ?", id).
+			Updates(map[string]any{
+				"username":       fmt.Sprintf("deleted-user-%d", id),
+				"email":          nil,
+				"reward_address": nil,
+				"display_name":   nil,
+				"avatar_url":     nil,
+				"bio":            nil,
+				"links":          nil,
+				"github_handle":  nil,
+				"purged_at":      time.Now(),
+			}).Error
+	})
+}
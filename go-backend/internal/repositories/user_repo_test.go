@@ -0,0 +1,348 @@
+package repositories_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+)
+
+// baseTime 测试数据的创建时间起点，精度与Postgres一致
+var baseTime = time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC)
+
+// createUser 在事务中创建用户及其web3认证方法，createdAt 为零时使用当前时间
+func createUser(t *testing.T, repo repositories.UserRepository, username string, role models.UserRole, createdAt time.Time) *models.User {
+	t.Helper()
+
+	user := &models.User{Username: username, Role: role, CreatedAt: createdAt}
+	err := repo.WithTx(func(repo repositories.UserRepository) error {
+		if err := repo.Create(user); err != nil {
+			return err
+		}
+		return repo.CreateAuthMethod(&models.AuthMethod{
+			UserID:         user.UserID,
+			AuthType:       models.AuthTypeWeb3,
+			AuthIdentifier: "0x" + username,
+		})
+	})
+	if err != nil {
+		t.Fatalf("create %s: %v", username, err)
+	}
+	return user
+}
+
+func usernames(users []models.User) []string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Username
+	}
+	return names
+}
+
+func TestUserRepositoryCreateAndFind(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewUserRepository(db)
+		alice := createUser(t, repo, "alice", models.UserRoleUser, time.Time{})
+
+		found, err := repo.FindByID(alice.UserID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Username != "alice" || found.Role != models.UserRoleUser {
+			t.Errorf("FindByID = %+v", found)
+		}
+		if len(found.AuthMethods) != 1 || found.AuthMethods[0].AuthIdentifier != "0xalice" {
+			t.Errorf("auth methods not preloaded: %+v", found.AuthMethods)
+		}
+
+		if _, err := repo.FindByID(alice.UserID + 100); !errors.Is(err, apperrors.ErrUserNotFound) {
+			t.Errorf("FindByID(missing) error = %v, want ErrUserNotFound", err)
+		}
+
+		byAuth, err := repo.FindByAuthMethod(models.AuthTypeWeb3, "0xalice")
+		if err != nil || byAuth == nil || byAuth.UserID != alice.UserID {
+			t.Errorf("FindByAuthMethod = %+v, %v", byAuth, err)
+		}
+		if missing, err := repo.FindByAuthMethod(models.AuthTypeWeb3, "0xnobody"); missing != nil || err != nil {
+			t.Errorf("FindByAuthMethod(missing) = %+v, %v, want nil, nil", missing, err)
+		}
+
+		byName, err := repo.FindByUsername("alice")
+		if err != nil || byName == nil || byName.UserID != alice.UserID {
+			t.Errorf("FindByUsername = %+v, %v", byName, err)
+		}
+		if missing, err := repo.FindByUsername("Alice"); missing != nil || err != nil {
+			t.Errorf("FindByUsername is case sensitive, got %+v, %v", missing, err)
+		}
+	})
+}
+
+func TestUserRepositoryDuplicateKeys(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewUserRepository(db)
+		alice := createUser(t, repo, "alice", models.UserRoleUser, time.Time{})
+
+		err := repo.Create(&models.User{Username: "alice", Role: models.UserRoleUser})
+		if !errors.Is(err, repositories.ErrDuplicateKey) {
+			t.Errorf("duplicate username error = %v, want ErrDuplicateKey", err)
+		}
+
+		bob := &models.User{Username: "bob", Role: models.UserRoleUser}
+		if err := repo.Create(bob); err != nil {
+			t.Fatal(err)
+		}
+		err = repo.CreateAuthMethod(&models.AuthMethod{UserID: bob.UserID, AuthType: models.AuthTypeWeb3, AuthIdentifier: "0xalice"})
+		if !errors.Is(err, repositories.ErrDuplicateKey) {
+			t.Errorf("duplicate auth method error = %v, want ErrDuplicateKey", err)
+		}
+
+		bob.Username = alice.Username
+		if err := repo.Update(bob); !errors.Is(err, repositories.ErrDuplicateKey) {
+			t.Errorf("update to taken username error = %v, want ErrDuplicateKey", err)
+		}
+	})
+}
+
+func TestUserRepositoryWithTxRollsBack(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewUserRepository(db)
+		errAbort := errors.New("abort")
+
+		err := repo.WithTx(func(repo repositories.UserRepository) error {
+			if err := repo.Create(&models.User{Username: "carol", Role: models.UserRoleUser}); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("WithTx error = %v, want %v", err, errAbort)
+		}
+		if user, err := repo.FindByUsername("carol"); user != nil || err != nil {
+			t.Errorf("user survived rollback: %+v, %v", user, err)
+		}
+	})
+}
+
+func TestUserRepositoryUpdate(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewUserRepository(db)
+		user := createUser(t, repo, "dave", models.UserRoleUser, time.Time{})
+
+		email := "dave@example.com"
+		user.Email = &email
+		user.Role = models.UserRoleDeveloper
+		user.Links = []models.ProfileLink{{Label: "blog", URL: "https://dave.example.com"}}
+		if err := repo.Update(user); err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := repo.FindByID(user.UserID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found.Email == nil || *found.Email != email || found.Role != models.UserRoleDeveloper {
+			t.Errorf("update not persisted: %+v", found)
+		}
+		if len(found.Links) != 1 || found.Links[0].URL != "https://dave.example.com" {
+			t.Errorf("links = %+v", found.Links)
+		}
+	})
+}
+
+func TestUserRepositoryListFilters(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewUserRepository(db)
+		createUser(t, repo, "Alice", models.UserRoleAdmin, baseTime)
+		createUser(t, repo, "al_bert", models.UserRoleDeveloper, baseTime.Add(time.Hour))
+		createUser(t, repo, "alfred", models.UserRoleUser, baseTime.Add(2*time.Hour))
+		createUser(t, repo, "bob", models.UserRoleUser, baseTime.Add(3*time.Hour))
+
+		role := models.UserRoleUser
+		authType := models.AuthTypeWeb3
+		identifier := "0xbob"
+		after := baseTime.Add(time.Hour)
+		before := baseTime.Add(3 * time.Hour)
+		tests := []struct {
+			name  string
+			query models.UserListQuery
+			want  []string
+		}{
+			{"all", models.UserListQuery{}, []string{"Alice", "al_bert", "alfred", "bob"}},
+			{"role", models.UserListQuery{Role: &role}, []string{"alfred", "bob"}},
+			{"auth method", models.UserListQuery{AuthType: &authType, AuthIdentifier: &identifier}, []string{"bob"}},
+			{"created range", models.UserListQuery{CreatedAfter: &after, CreatedBefore: &before}, []string{"al_bert", "alfred"}},
+			// 前缀不区分大小写，_ 按字面匹配而不是通配符
+			{"prefix", models.UserListQuery{UsernamePrefix: ptr("AL")}, []string{"Alice", "al_bert", "alfred"}},
+			{"escaped prefix", models.UserListQuery{UsernamePrefix: ptr("al_")}, []string{"al_bert"}},
+			{"offset", models.UserListQuery{Offset: 1, Limit: 2}, []string{"al_bert", "alfred"}},
+			{"sort desc", models.UserListQuery{SortBy: models.UserSortByCreatedAt, Order: models.SortDesc}, []string{"bob", "alfred", "al_bert", "Alice"}},
+		}
+		for _, tt := range tests {
+			page, err := repo.List(&tt.query)
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if got := usernames(page.Users); !slices.Equal(got, tt.want) {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			}
+		}
+
+		page, err := repo.List(&models.UserListQuery{Role: &role, Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Pagination.Total != 2 || page.Pagination.Limit != 1 || page.Pagination.NextCursor == "" {
+			t.Errorf("pagination = %+v", page.Pagination)
+		}
+	})
+}
+
+func TestUserRepositoryListCursor(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewUserRepository(db)
+		var want []string
+		for i := range 7 {
+			name := fmt.Sprintf("user%02d", i)
+			// 两两相同的创建时间，检查以 user_id 作为次级排序
+			createUser(t, repo, name, models.UserRoleUser, baseTime.Add(time.Duration(i/2)*time.Minute))
+			want = append(want, name)
+		}
+
+		for _, sortBy := range []models.UserSortField{models.UserSortByID, models.UserSortByUsername, models.UserSortByCreatedAt} {
+			for _, order := range []models.SortOrder{models.SortAsc, models.SortDesc} {
+				query := models.UserListQuery{SortBy: sortBy, Order: order, Limit: 3}
+				var got []string
+				for {
+					page, err := repo.List(&query)
+					if err != nil {
+						t.Fatalf("%s %s: %v", sortBy, order, err)
+					}
+					got = append(got, usernames(page.Users)...)
+					if page.Pagination.NextCursor == "" {
+						break
+					}
+					query.Cursor = page.Pagination.NextCursor
+				}
+
+				expected := slices.Clone(want)
+				if order == models.SortDesc {
+					slices.Reverse(expected)
+				}
+				if !slices.Equal(got, expected) {
+					t.Errorf("%s %s: got %v, want %v", sortBy, order, got, expected)
+				}
+			}
+		}
+
+		if _, err := repo.List(&models.UserListQuery{Cursor: "not a cursor"}); !errors.Is(err, apperrors.ErrInvalidCursor) {
+			t.Errorf("invalid cursor error = %v, want ErrInvalidCursor", err)
+		}
+
+		// 换用其他排序字段或方向复用游标应报校验错误，而不是返回错误的页
+		page, err := repo.List(&models.UserListQuery{SortBy: models.UserSortByCreatedAt, Limit: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, query := range []models.UserListQuery{
+			{SortBy: models.UserSortByUsername},
+			{SortBy: models.UserSortByCreatedAt, Order: models.SortDesc},
+			{},
+		} {
+			query.Cursor = page.Pagination.NextCursor
+			if _, err := repo.List(&query); !errors.Is(err, apperrors.ErrValidation) {
+				t.Errorf("%s %s: mismatched cursor error = %v, want ErrValidation", query.SortBy, query.Order, err)
+			}
+		}
+	})
+}
+
+func TestUserRepositorySoftDeleteLifecycle(t *testing.T) {
+	forEachDatabase(t, func(t *testing.T, db *gorm.DB) {
+		repo := repositories.NewUserRepository(db)
+		erin := createUser(t, repo, "erin", models.UserRoleUser, time.Time{})
+		frank := createUser(t, repo, "frank", models.UserRoleUser, time.Time{})
+
+		if err := repo.Delete(erin.UserID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.FindByID(erin.UserID); !errors.Is(err, apperrors.ErrUserNotFound) {
+			t.Errorf("FindByID(deleted) error = %v, want ErrUserNotFound", err)
+		}
+		if user, err := repo.FindByAuthMethod(models.AuthTypeWeb3, "0xerin"); user != nil || err != nil {
+			t.Errorf("FindByAuthMethod(deleted) = %+v, %v, want nil, nil", user, err)
+		}
+		// 宽限期内用户名仍被占用
+		if user, err := repo.FindByUsername("erin"); err != nil || user == nil || user.UserID != erin.UserID {
+			t.Errorf("FindByUsername(deleted) = %+v, %v", user, err)
+		}
+		deleted, err := repo.FindDeletedByAuthMethod(models.AuthTypeWeb3, "0xerin")
+		if err != nil || deleted == nil || deleted.UserID != erin.UserID {
+			t.Errorf("FindDeletedByAuthMethod = %+v, %v", deleted, err)
+		}
+		if user, err := repo.FindDeletedByAuthMethod(models.AuthTypeWeb3, "0xfrank"); user != nil || err != nil {
+			t.Errorf("FindDeletedByAuthMethod(active) = %+v, %v, want nil, nil", user, err)
+		}
+
+		page, err := repo.List(&models.UserListQuery{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := usernames(page.Users); !slices.Equal(got, []string{"frank"}) || page.Pagination.Total != 1 {
+			t.Errorf("List after delete = %v (total %d)", got, page.Pagination.Total)
+		}
+
+		// 截止时间在删除之前时不可清理，使用本地时区检查SQLite的时间比较与Postgres一致
+		purgeable, err := repo.FindPurgeable(time.Now().Add(-time.Hour).Local(), 10)
+		if err != nil || len(purgeable) != 0 {
+			t.Errorf("FindPurgeable(before deletion) = %v, %v", usernames(purgeable), err)
+		}
+		purgeable, err = repo.FindPurgeable(time.Now().Add(time.Hour).In(time.FixedZone("UTC+8", 8*3600)), 10)
+		if err != nil || !slices.Equal(usernames(purgeable), []string{"erin"}) {
+			t.Errorf("FindPurgeable(after deletion) = %v, %v", usernames(purgeable), err)
+		}
+
+		if err := repo.Restore(erin.UserID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.FindByID(erin.UserID); err != nil {
+			t.Errorf("FindByID(restored) error = %v", err)
+		}
+		if err := repo.Restore(frank.UserID); !errors.Is(err, apperrors.ErrUserNotFound) {
+			t.Errorf("Restore(active) error = %v, want ErrUserNotFound", err)
+		}
+
+		if err := repo.Delete(erin.UserID); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Purge(erin.UserID); err != nil {
+			t.Fatal(err)
+		}
+		if user, err := repo.FindByUsername("erin"); user != nil || err != nil {
+			t.Errorf("username still taken after purge: %+v, %v", user, err)
+		}
+		purged, err := repo.FindByUsername(fmt.Sprintf("deleted-user-%d", erin.UserID))
+		if err != nil || purged == nil || purged.PurgedAt == nil || purged.Email != nil {
+			t.Errorf("purged user = %+v, %v", purged, err)
+		}
+		if user, err := repo.FindDeletedByAuthMethod(models.AuthTypeWeb3, "0xerin"); user != nil || err != nil {
+			t.Errorf("auth method survived purge: %+v, %v", user, err)
+		}
+		if err := repo.Restore(erin.UserID); !errors.Is(err, apperrors.ErrUserNotFound) {
+			t.Errorf("Restore(purged) error = %v, want ErrUserNotFound", err)
+		}
+		if purgeable, err := repo.FindPurgeable(time.Now().Add(time.Hour), 10); err != nil || len(purgeable) != 0 {
+			t.Errorf("FindPurgeable after purge = %v, %v", usernames(purgeable), err)
+		}
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,25 @@
+// Package reqctx 在Fiber请求上保存请求范围的context，处理器通过它把span、
+// 请求日志记录器等传给服务层
+package reqctx
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// localsKey 请求context在Locals中的键
+const localsKey = "reqctx:context"
+
+// From 返回请求的context，未经过任何设置context的中间件时返回 context.Background
+func From(c fiber.Ctx) context.Context {
+	if ctx, ok := c.Locals(localsKey).(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
+
+// Set 替换请求的context，中间件用它附加请求范围的值
+func Set(c fiber.Ctx, ctx context.Context) {
+	c.Locals(localsKey, ctx)
+}
@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/handlers"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// SetupNodeCompat 挂载Node.js版本的路由路径（无 /api/v1 前缀），
+// 响应转换为Node.js版本的格式，前端无需修改即可切换到Go后端。
+//
+// 以下接口Go版本尚未实现，返回501：
+//   - POST /user：账户只能通过钱包登录创建
+//   - POST /user/:id/bind-auth：绑定认证方式需要签名验证流程
+//   - GET /user/auth/github、GET|POST /user/auth/github/callback：未接入GitHub OAuth
+//   - POST /mcpserver、DELETE /mcpserver/:name：部署依赖Kubernetes
+//   - POST /mcpcard、POST /mcpcard/import：导入依赖GitHub
+//
+// GET /mcpserver 返回数据库中记录的服务器，格式与Kubernetes资源一致，但不含运行时字段
+func (r *Routes) SetupNodeCompat() {
+	compat := middleware.NodeCompat()
+
+	// 认证服务状态无需登录
+	r.app.Get("/auth/status", compat, r.web3Handler.AuthStatus) // GET /auth/status
+	authGroup := r.app.Group("/auth", compat, r.auth)
+	authGroup.Get("/me", r.web3Handler.GetSession)                 // GET /auth/me
+	authGroup.Get("/bearer-token", r.web3Handler.IssueBearerToken) // GET /auth/bearer-token
+
+	// 在 /user 的认证中间件之前注册，未登录时同样返回501
+	github := handlers.NotImplemented("GitHub login")
+	r.app.Get("/user/auth/github", compat, github)           // GET /user/auth/github
+	r.app.Get("/user/auth/github/callback", compat, github)  // GET /user/auth/github/callback
+	r.app.Post("/user/auth/github/callback", compat, github) // POST /user/auth/github/callback
+
+	userGroup := r.app.Group("/user", compat, r.auth)
+
+	// 与 /api/v1/user 相同，列表只对管理员开放
+	userGroup.Get("/", middleware.RequireRole(models.UserRoleAdmin), r.userHandler.GetUsers) // GET /user
+
+	userGroup.Post("/", handlers.NotImplemented("Creating users directly"))                      // POST /user
+	userGroup.Get("/by-auth", r.userHandler.GetUserByAuth)                                       // GET /user/by-auth
+	userGroup.Get("/:id", r.userHandler.GetUserByID)                                             // GET /user/:id
+	userGroup.Put("/:id", r.userHandler.UpdateUser)                                              // PUT /user/:id
+	userGroup.Delete("/:id", r.userHandler.DeleteUser)                                           // DELETE /user/:id
+	userGroup.Post("/:id/bind-auth", handlers.NotImplemented("Binding additional auth methods")) // POST /user/:id/bind-auth
+
+	userAuthGroup := userGroup.Group("/auth")
+	userAuthGroup.Post("/logout", r.web3Handler.Logout) // POST /user/auth/logout
+
+	web3Group := userAuthGroup.Group("/web3")
+	web3Group.Get("/challenge", r.web3Handler.GetWeb3Challenge) // GET /user/auth/web3/challenge
+	web3Group.Post("/verify", r.web3Handler.VerifyWeb3Auth)     // POST /user/auth/web3/verify
+
+	// MCP服务器和卡片与Node.js版本一样无需登录即可读取
+	serverGroup := r.app.Group("/mcpserver", compat)
+	serverGroup.Get("/", r.mcpHandler.ListServerResources)                        // GET /mcpserver
+	serverGroup.Get("/:name", r.mcpHandler.GetServerResource)                     // GET /mcpserver/:name
+	serverGroup.Post("/", handlers.NotImplemented("Deploying MCP servers"))       // POST /mcpserver
+	serverGroup.Delete("/:name", handlers.NotImplemented("Deleting MCP servers")) // DELETE /mcpserver/:name
+
+	cardGroup := r.app.Group("/mcpcard", compat)
+	cardGroup.Get("/", r.mcpHandler.ListCards)                                // GET /mcpcard
+	cardGroup.Get("/:id", r.mcpHandler.GetCard)                               // GET /mcpcard/:id
+	cardGroup.Post("/", handlers.NotImplemented("Creating MCP cards"))        // POST /mcpcard
+	cardGroup.Post("/import", handlers.NotImplemented("Importing MCP cards")) // POST /mcpcard/import
+}
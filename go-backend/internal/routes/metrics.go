@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+)
+
+// SetupMetrics 挂载Prometheus指标接口，token为空时不校验（仅限非生产环境）
+func (r *Routes) SetupMetrics(handler fiber.Handler, token string) {
+	r.app.Get("/metrics", middleware.RequireBearerToken(token), handler) // GET /metrics
+}
@@ -0,0 +1,161 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/buildinfo"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/openapi"
+)
+
+const (
+	apiPrefix      = "/api/v1"
+	openAPISpecURL = apiPrefix + "/openapi.json"
+	openAPIDocsURL = apiPrefix + "/docs"
+)
+
+// apiEnums 文档中以 enum 列出的字符串类型
+var apiEnums = []openapi.Enum{
+	openapi.EnumOf(models.UserRoleUser, models.UserRoleDeveloper, models.UserRoleAdmin),
+	openapi.EnumOf(models.AuthTypeWeb3, models.AuthTypeGoogle, models.AuthTypeGitHub),
+	openapi.EnumOf(models.RewardAddressPending, models.RewardAddressActive, models.RewardAddressSuperseded, models.RewardAddressCancelled),
+	openapi.EnumOf(models.RoleApplicationPending, models.RoleApplicationApproved, models.RoleApplicationRejected),
+	openapi.EnumOf(models.RoleApplicationSubmitted, models.RoleApplicationApprove, models.RoleApplicationReject, models.RoleApplicationProvisioned),
+}
+
+// queryParam 由处理器手动解析的查询参数
+func queryParam(name, description string, schema *openapi.Schema) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func enumSchema(values ...string) *openapi.Schema {
+	s := &openapi.Schema{Type: "string"}
+	for _, v := range values {
+		s.Enum = append(s.Enum, v)
+	}
+	return s
+}
+
+// userListParams GET /user 的查询参数，与 handlers.parseUserListQuery 对应
+var userListParams = []openapi.Parameter{
+	queryParam("role", "", enumSchema(string(models.UserRoleUser), string(models.UserRoleDeveloper), string(models.UserRoleAdmin))),
+	queryParam("auth_type", "", enumSchema(string(models.AuthTypeWeb3), string(models.AuthTypeGoogle), string(models.AuthTypeGitHub))),
+	queryParam("auth_identifier", "Requires auth_type", &openapi.Schema{Type: "string"}),
+	queryParam("username_prefix", "", &openapi.Schema{Type: "string"}),
+	queryParam("email_prefix", "", &openapi.Schema{Type: "string"}),
+	queryParam("created_after", "RFC3339 timestamp", &openapi.Schema{Type: "string", Format: "date-time"}),
+	queryParam("created_before", "RFC3339 timestamp", &openapi.Schema{Type: "string", Format: "date-time"}),
+	queryParam("sort", "", enumSchema(string(models.UserSortByID), string(models.UserSortByUsername), string(models.UserSortByCreatedAt), string(models.UserSortByUpdatedAt))),
+	queryParam("order", "", enumSchema(string(models.SortAsc), string(models.SortDesc))),
+	queryParam("limit", "", &openapi.Schema{Type: "integer", Format: "int32"}),
+	queryParam("offset", "Ignored when cursor is set", &openapi.Schema{Type: "integer", Format: "int32"}),
+	queryParam("cursor", "Cursor from pagination.next_cursor", &openapi.Schema{Type: "string"}),
+}
+
+// apiEndpoints /api/v1 下所有接口的文档，新增路由时需同步添加，否则漂移测试失败
+var apiEndpoints = []openapi.Endpoint{
+	{Method: fiber.MethodGet, Path: "/api/v1/status", OperationID: "getStatus", Summary: "Service status and build info", Tag: "status",
+		Response: models.ServiceStatus{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/profiles/:username", OperationID: "getPublicProfile", Summary: "Public profile with published servers and cards", Tag: "profiles",
+		Response: models.PublicProfile{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/mcp/servers", OperationID: "listMCPServers", Summary: "List MCP servers, newest first", Tag: "mcp",
+		Response: []models.MCPServer{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/mcp/servers/:name", OperationID: "getMCPServer", Summary: "Get an MCP server by name", Tag: "mcp",
+		Response: models.MCPServer{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/mcp/cards", OperationID: "listMCPCards", Summary: "List MCP marketplace cards, newest first", Tag: "mcp",
+		Response: []models.MCPCard{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/mcp/cards/:id", OperationID: "getMCPCard", Summary: "Get an MCP marketplace card", Tag: "mcp",
+		Response: models.MCPCard{}},
+
+	{Method: fiber.MethodGet, Path: "/api/v1/user", OperationID: "listUsers", Summary: "List users", Tag: "users", Auth: openapi.AuthAdmin,
+		Params: userListParams, Response: []models.User{}, Pagination: models.Pagination{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/user/me/export", OperationID: "exportMyData", Summary: "Download all personal data of the current user", Tag: "users", Auth: openapi.AuthUser,
+		Response: models.UserDataExport{}, Raw: true},
+	{Method: fiber.MethodGet, Path: "/api/v1/user/:id", OperationID: "getUser", Summary: "Get a user", Tag: "users", Auth: openapi.AuthUser,
+		Response: models.User{}},
+	{Method: fiber.MethodPut, Path: "/api/v1/user/:id", OperationID: "updateUser", Summary: "Update a user", Tag: "users", Auth: openapi.AuthUser,
+		Body: models.UpdateUserRequest{}, Response: models.User{}},
+	{Method: fiber.MethodDelete, Path: "/api/v1/user/:id", OperationID: "deleteUser", Summary: "Delete a user, restorable during the grace period", Tag: "users", Auth: openapi.AuthUser,
+		Response: models.DeleteUserResponse{}},
+
+	{Method: fiber.MethodGet, Path: "/api/v1/user/me/reward-address", OperationID: "getRewardAddress", Summary: "Current reward address, pending change and history", Tag: "reward-address", Auth: openapi.AuthUser,
+		Response: models.RewardAddressOverview{}},
+	{Method: fiber.MethodPost, Path: "/api/v1/user/me/reward-address/challenge", OperationID: "getRewardAddressChallenge", Summary: "Ownership challenge for a new reward address", Tag: "reward-address", Auth: openapi.AuthUser,
+		Body: models.RewardAddressChallengeRequest{}, Response: models.Web3ChallengeResponse{}},
+	{Method: fiber.MethodPost, Path: "/api/v1/user/me/reward-address", OperationID: "requestRewardAddressChange", Summary: "Schedule a signed reward address change", Tag: "reward-address", Auth: openapi.AuthUser,
+		Body: models.RewardAddressChangeRequest{}, Response: models.RewardAddressChange{}},
+	{Method: fiber.MethodDelete, Path: "/api/v1/user/me/reward-address/pending", OperationID: "cancelRewardAddressChange", Summary: "Cancel the pending reward address change", Tag: "reward-address", Auth: openapi.AuthUser,
+		Response: models.RewardAddressChange{}},
+
+	{Method: fiber.MethodPost, Path: "/api/v1/user/me/developer-application", OperationID: "submitDeveloperApplication", Summary: "Apply for the developer role", Tag: "role-applications", Auth: openapi.AuthUser,
+		Body: models.RoleApplicationRequest{}, Response: models.RoleApplication{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/user/me/developer-application", OperationID: "listMyDeveloperApplications", Summary: "Own developer applications", Tag: "role-applications", Auth: openapi.AuthUser,
+		Response: []models.RoleApplication{}},
+
+	{Method: fiber.MethodGet, Path: "/api/v1/user/auth/web3/challenge", OperationID: "getWeb3Challenge", Summary: "Sign-in challenge for a wallet address", Tag: "auth",
+		Query: models.Web3ChallengeRequest{}, Response: models.Web3ChallengeResponse{}},
+	{Method: fiber.MethodPost, Path: "/api/v1/user/auth/web3/verify", OperationID: "verifyWeb3Auth", Summary: "Verify a signed challenge and sign in or register", Tag: "auth",
+		Body: models.Web3AuthRequest{}, Response: models.Web3AuthResponse{}},
+	{Method: fiber.MethodPost, Path: "/api/v1/user/auth/logout", OperationID: "logout", Summary: "Clear the auth cookie", Tag: "auth",
+		Response: models.MessageResponse{}},
+
+	{Method: fiber.MethodGet, Path: "/api/v1/admin/role-applications", OperationID: "listRoleApplications", Summary: "List role applications", Tag: "admin", Auth: openapi.AuthAdmin,
+		Params:   []openapi.Parameter{queryParam("status", "", enumSchema(string(models.RoleApplicationPending), string(models.RoleApplicationApproved), string(models.RoleApplicationRejected)))},
+		Response: []models.RoleApplication{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/admin/role-applications/:id", OperationID: "getRoleApplication", Summary: "Role application with audit events", Tag: "admin", Auth: openapi.AuthAdmin,
+		Response: models.RoleApplication{}},
+	{Method: fiber.MethodPost, Path: "/api/v1/admin/role-applications/:id/approve", OperationID: "approveRoleApplication", Summary: "Approve a role application", Tag: "admin", Auth: openapi.AuthAdmin,
+		Body: models.RoleApplicationReviewRequest{}, BodyOptional: true, Response: models.RoleApplication{}},
+	{Method: fiber.MethodPost, Path: "/api/v1/admin/role-applications/:id/reject", OperationID: "rejectRoleApplication", Summary: "Reject a role application", Tag: "admin", Auth: openapi.AuthAdmin,
+		Body: models.RoleApplicationReviewRequest{}, BodyOptional: true, Response: models.RoleApplication{}},
+	{Method: fiber.MethodGet, Path: "/api/v1/admin/log-level", OperationID: "getLogLevel", Summary: "Current log level", Tag: "admin", Auth: openapi.AuthAdmin,
+		Response: models.LogLevelResponse{}},
+	{Method: fiber.MethodPut, Path: "/api/v1/admin/log-level", OperationID: "setLogLevel", Summary: "Change the log level at runtime", Tag: "admin", Auth: openapi.AuthAdmin,
+		Body: models.LogLevelRequest{}, Response: models.LogLevelResponse{}},
+}
+
+// OpenAPI 根据已注册的 /api/v1 路由生成文档，同时返回路由与文档的差异
+func (r *Routes) OpenAPI() (*openapi.Document, []string) {
+	var routes []fiber.Route
+	for _, route := range r.app.GetRoutes(true) {
+		if !strings.HasPrefix(route.Path, apiPrefix+"/") {
+			continue
+		}
+		if route.Path == openAPISpecURL || route.Path == openAPIDocsURL {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	doc, drift := openapi.Build(openapi.Info{
+		Title:   "MCPForge API",
+		Version: buildinfo.Get().Version,
+	}, routes, apiEndpoints, apiEnums)
+	doc.Servers = []openapi.Server{{URL: "/"}}
+	return doc, drift
+}
+
+// SetupOpenAPI 挂载OpenAPI文档和文档页面，需在 Setup 之后调用
+func (r *Routes) SetupOpenAPI() {
+	doc, _ := r.OpenAPI()
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		panic(fmt.Sprintf("openapi: marshal document: %v", err))
+	}
+	page := openapi.DocsHTML("MCPForge API", openAPISpecURL)
+	csp := openapi.DocsCSP(openAPISpecURL)
+
+	r.app.Get(openAPISpecURL, func(c fiber.Ctx) error { // GET /api/v1/openapi.json
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(spec)
+	})
+	r.app.Get(openAPIDocsURL, func(c fiber.Ctx) error { // GET /api/v1/docs
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		c.Set(fiber.HeaderContentSecurityPolicy, csp)
+		return c.SendString(page)
+	})
+}
@@ -0,0 +1,122 @@
+package routes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/app"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// newTestRoutes 注册全部路由，处理器只用于路由表，不会被调用
+func newTestRoutes(t *testing.T) *Routes {
+	t.Helper()
+	a := app.New(config.Default(), logger.New("error"))
+	noAuth := func(c fiber.Ctx) error { return c.Next() }
+	r := NewRoutes(a, noAuth, nil, nil, nil, nil, nil, nil, nil, nil)
+	r.Setup()
+	r.SetupOpenAPI()
+	return r
+}
+
+// TestOpenAPIMatchesRoutes 路由和文档不一致时失败，新增或删除 /api/v1 路由时需同步修改 apiEndpoints
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	_, drift := newTestRoutes(t).OpenAPI()
+	for _, d := range drift {
+		t.Error(d)
+	}
+}
+
+func TestOpenAPIDocumentIsValid(t *testing.T) {
+	doc, _ := newTestRoutes(t).OpenAPI()
+
+	operationIDs := make(map[string]string)
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			if op.OperationID == "" {
+				t.Errorf("%s %s has no operationId", method, path)
+			}
+			if other, ok := operationIDs[op.OperationID]; ok {
+				t.Errorf("operationId %s used by both %s and %s %s", op.OperationID, other, method, path)
+			}
+			operationIDs[op.OperationID] = method + " " + path
+		}
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("marshal document: %v", err)
+	}
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		t.Fatalf("unmarshal document: %v", err)
+	}
+	checkRefs(t, tree, tree)
+}
+
+// checkRefs 确认所有 $ref 都指向文档中存在的节点
+func checkRefs(t *testing.T, root, node any) {
+	t.Helper()
+	switch v := node.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok && !resolveRef(root, ref) {
+			t.Errorf("unresolved $ref %s", ref)
+		}
+		for _, child := range v {
+			checkRefs(t, root, child)
+		}
+	case []any:
+		for _, child := range v {
+			checkRefs(t, root, child)
+		}
+	}
+}
+
+func resolveRef(root any, ref string) bool {
+	if !strings.HasPrefix(ref, "#/") {
+		return false
+	}
+	node := root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return false
+		}
+		if node, ok = m[part]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOpenAPIServed(t *testing.T) {
+	r := newTestRoutes(t)
+
+	for path, contentType := range map[string]string{
+		openAPISpecURL: fiber.MIMEApplicationJSON,
+		openAPIDocsURL: fiber.MIMETextHTML,
+	} {
+		resp, err := r.app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s: status %d", path, resp.StatusCode)
+		}
+		if !strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), contentType) {
+			t.Errorf("GET %s: content type %q", path, resp.Header.Get(fiber.HeaderContentType))
+		}
+		if path == openAPISpecURL && !json.Valid(body) {
+			t.Errorf("GET %s: invalid JSON", path)
+		}
+	}
+}
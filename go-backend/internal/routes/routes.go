@@ -1,23 +1,39 @@
 package routes
 
 import (
+	"github.com/gofiber/fiber/v3"
+
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/app"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/handlers"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
 )
 
 type Routes struct {
-	app           *app.App
-	healthHandler *handlers.HealthHandler
-	userHandler   *handlers.UserHandler
-	web3Handler   *handlers.Web3Handler
+	app            *app.App
+	auth           fiber.Handler
+	healthHandler  *handlers.HealthHandler
+	userHandler    *handlers.UserHandler
+	web3Handler    *handlers.Web3Handler
+	rewardHandler  *handlers.RewardAddressHandler
+	roleHandler    *handlers.RoleApplicationHandler
+	profileHandler *handlers.ProfileHandler
+	mcpHandler     *handlers.MCPHandler
+	logHandler     *handlers.LogLevelHandler
 }
 
-func NewRoutes(app *app.App, healthHandler *handlers.HealthHandler, userHandler *handlers.UserHandler, web3Handler *handlers.Web3Handler) *Routes {
+func NewRoutes(app *app.App, auth fiber.Handler, healthHandler *handlers.HealthHandler, userHandler *handlers.UserHandler, web3Handler *handlers.Web3Handler, rewardHandler *handlers.RewardAddressHandler, roleHandler *handlers.RoleApplicationHandler, profileHandler *handlers.ProfileHandler, mcpHandler *handlers.MCPHandler, logHandler *handlers.LogLevelHandler) *Routes {
 	return &Routes{
-		app:           app,
-		healthHandler: healthHandler,
-		userHandler:   userHandler,
-		web3Handler:   web3Handler,
+		app:            app,
+		auth:           auth,
+		healthHandler:  healthHandler,
+		userHandler:    userHandler,
+		web3Handler:    web3Handler,
+		rewardHandler:  rewardHandler,
+		roleHandler:    roleHandler,
+		profileHandler: profileHandler,
+		mcpHandler:     mcpHandler,
+		logHandler:     logHandler,
 	}
 }
 
@@ -25,19 +41,46 @@ func (r *Routes) Setup() {
 	// 健康检查路由
 	r.app.Get("/", r.healthHandler.HealthCheck)
 	r.app.Get("/health", r.healthHandler.HealthCheck)
-	r.app.Get("/ready", r.healthHandler.ReadyCheck)
+	r.app.Get("/livez", r.healthHandler.Livez)
+	r.app.Get("/readyz", r.healthHandler.Readyz)
+	r.app.Get("/ready", r.healthHandler.Readyz) // 兼容旧的就绪检查路径
 	
 	// API v1 路由组
 	api := r.app.Group("/api/v1")
 	api.Get("/status", r.healthHandler.HealthCheck)
 	
+	// 公开资料，无需登录
+	api.Get("/profiles/:username", r.profileHandler.GetPublicProfile) // GET /api/v1/profiles/:username
+	
+	// MCP服务器和卡片公开可读，部署和发布尚未实现
+	mcpGroup := api.Group("/mcp")
+	mcpGroup.Get("/servers", r.mcpHandler.ListServers)       // GET /api/v1/mcp/servers
+	mcpGroup.Get("/servers/:name", r.mcpHandler.GetServer)   // GET /api/v1/mcp/servers/:name
+	mcpGroup.Get("/cards", r.mcpHandler.ListCards)           // GET /api/v1/mcp/cards
+	mcpGroup.Get("/cards/:id", r.mcpHandler.GetCard)         // GET /api/v1/mcp/cards/:id
+	
 	// 用户路由 - 保持与Node.js版本的API兼容性
-	userGroup := api.Group("/user")
+	// 认证中间件会跳过 /auth/web3 路径
+	userGroup := api.Group("/user", r.auth)
 	
 	// 基础用户CRUD
 	// 只允许通过钱包登录注册
 	// userGroup.Post("/", r.userHandler.CreateUser)           // POST /api/v1/user
-	userGroup.Get("/", r.userHandler.GetUsers)              // GET /api/v1/user  
+	// 列表包含邮箱、收款地址和认证方式，只对管理员开放
+	userGroup.Get("/", middleware.RequireRole(models.UserRoleAdmin), r.userHandler.GetUsers) // GET /api/v1/user
+	userGroup.Get("/me/export", r.userHandler.ExportMyData) // GET /api/v1/user/me/export
+	
+	// 收款地址只能通过签名验证后的变更流程修改
+	rewardGroup := userGroup.Group("/me/reward-address")
+	rewardGroup.Get("/", r.rewardHandler.GetRewardAddress)           // GET /api/v1/user/me/reward-address
+	rewardGroup.Post("/challenge", r.rewardHandler.GetChallenge)     // POST /api/v1/user/me/reward-address/challenge
+	rewardGroup.Post("/", r.rewardHandler.RequestChange)             // POST /api/v1/user/me/reward-address
+	rewardGroup.Delete("/pending", r.rewardHandler.CancelPending)    // DELETE /api/v1/user/me/reward-address/pending
+	
+	// 开发者角色只能通过申请并经管理员审核获得
+	userGroup.Post("/me/developer-application", r.roleHandler.Submit)  // POST /api/v1/user/me/developer-application
+	userGroup.Get("/me/developer-application", r.roleHandler.ListMine) // GET /api/v1/user/me/developer-application
+	
 	userGroup.Get("/:id", r.userHandler.GetUserByID)        // GET /api/v1/user/:id
 	userGroup.Put("/:id", r.userHandler.UpdateUser)         // PUT /api/v1/user/:id
 	userGroup.Delete("/:id", r.userHandler.DeleteUser)      // DELETE /api/v1/user/:id
@@ -48,4 +91,15 @@ func (r *Routes) Setup() {
 	
 	web3Group.Get("/challenge", r.web3Handler.GetWeb3Challenge)  // GET /api/v1/user/auth/web3/challenge
 	web3Group.Post("/verify", r.web3Handler.VerifyWeb3Auth)      // POST /api/v1/user/auth/web3/verify
+	authGroup.Post("/logout", r.web3Handler.Logout)              // POST /api/v1/user/auth/logout
+	
+	// 管理员路由
+	adminGroup := api.Group("/admin", r.auth, middleware.RequireRole(models.UserRoleAdmin))
+	applicationGroup := adminGroup.Group("/role-applications")
+	applicationGroup.Get("/", r.roleHandler.List)                 // GET /api/v1/admin/role-applications
+	applicationGroup.Get("/:id", r.roleHandler.Get)               // GET /api/v1/admin/role-applications/:id
+	applicationGroup.Post("/:id/approve", r.roleHandler.Approve)  // POST /api/v1/admin/role-applications/:id/approve
+	applicationGroup.Post("/:id/reject", r.roleHandler.Reject)    // POST /api/v1/admin/role-applications/:id/reject
+	adminGroup.Get("/log-level", r.logHandler.GetLogLevel)        // GET /api/v1/admin/log-level
+	adminGroup.Put("/log-level", r.logHandler.SetLogLevel)        // PUT /api/v1/admin/log-level
 }
\ No newline at end of file
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/tracing"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// purgeBatchSize 每轮清理的最大账户数
+const purgeBatchSize = 100
+
+// RestoreUser 恢复处于删除宽限期内的用户
+func (s *UserService) RestoreUser(ctx context.Context, user *models.User) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.RestoreUser", attribute.Int("user.id", int(user.UserID)))
+	defer func() { tracing.End(span, err) }()
+
+	if !user.DeletedAt.Valid {
+		return nil
+	}
+	if time.Since(user.DeletedAt.Time) > s.deletionGracePeriod {
+		return apperrors.ErrDeletionGraceExpired
+	}
+
+	if err := s.userRepo.WithContext(ctx).Restore(user.UserID); err != nil {
+		return err
+	}
+
+	s.userCache.Invalidate(user.UserID)
+	return nil
+}
+
+// PurgeExpiredAccounts 匿名化超过删除宽限期的账户，返回处理数量
+func (s *UserService) PurgeExpiredAccounts(ctx context.Context) (purged int, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.PurgeExpiredAccounts")
+	defer func() {
+		span.SetAttributes(attribute.Int("accounts.purged", purged))
+		tracing.End(span, err)
+	}()
+
+	repo := s.userRepo.WithContext(ctx)
+	deletedBefore := time.Now().Add(-s.deletionGracePeriod)
+	for {
+		users, err := repo.FindPurgeable(deletedBefore, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, user := range users {
+			if err := repo.Purge(user.UserID); err != nil {
+				return purged, err
+			}
+			s.userCache.Invalidate(user.UserID)
+			purged++
+		}
+
+		if len(users) < purgeBatchSize {
+			return purged, nil
+		}
+	}
+}
+
+// RunAccountPurger 定期清理过期账户，直到ctx被取消
+func (s *UserService) RunAccountPurger(ctx context.Context, interval time.Duration, l *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.PurgeExpiredAccounts(ctx)
+		if err != nil {
+			l.Error("Failed to purge deleted accounts", "error", err.Error(), "purged", purged)
+		} else if purged > 0 {
+			l.Info("Purged deleted accounts", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
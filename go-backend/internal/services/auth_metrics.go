@@ -0,0 +1,17 @@
+package services
+
+// AuthMetrics 记录钱包认证结果，用于监控登录成功率
+type AuthMetrics interface {
+	ChallengeIssued()
+	// AuthSucceeded action 为 login、register 或 restore
+	AuthSucceeded(action string)
+	// AuthFailed reason 为错误码
+	AuthFailed(reason string)
+}
+
+// NopAuthMetrics 不记录任何指标
+type NopAuthMetrics struct{}
+
+func (NopAuthMetrics) ChallengeIssued()     {}
+func (NopAuthMetrics) AuthSucceeded(string) {}
+func (NopAuthMetrics) AuthFailed(string)    {}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/tracing"
+)
+
+// ExportService 汇总用户在各模块中的个人数据
+type ExportService struct {
+	userRepo        repositories.UserRepository
+	rewardRepo      repositories.RewardAddressRepository
+	applicationRepo repositories.RoleApplicationRepository
+	mcpRepo         repositories.MCPRepository
+}
+
+// NewExportService 创建个人数据导出服务
+func NewExportService(userRepo repositories.UserRepository, rewardRepo repositories.RewardAddressRepository, applicationRepo repositories.RoleApplicationRepository, mcpRepo repositories.MCPRepository) *ExportService {
+	return &ExportService{
+		userRepo:        userRepo,
+		rewardRepo:      rewardRepo,
+		applicationRepo: applicationRepo,
+		mcpRepo:         mcpRepo,
+	}
+}
+
+// ExportUserData 导出用户的全部个人数据，包括自己发布的MCP服务器和卡片。
+// 导出需要多次查询，每次查询前检查ctx，请求取消后不再继续
+func (s *ExportService) ExportUserData(ctx context.Context, id uint) (export *models.UserDataExport, err error) {
+	ctx, span := tracing.Start(ctx, "ExportService.ExportUserData", attribute.Int("user.id", int(id)))
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	authMethods := user.AuthMethods
+	if authMethods == nil {
+		authMethods = []models.AuthMethod{}
+	}
+	profile := *user
+	profile.AuthMethods = nil
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	rewardHistory, err := s.rewardRepo.ListByUser(id)
+	if err != nil {
+		return nil, err
+	}
+	if rewardHistory == nil {
+		rewardHistory = []models.RewardAddressChange{}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	applications, err := s.applicationRepo.ListByUser(id)
+	if err != nil {
+		return nil, err
+	}
+	if applications == nil {
+		applications = []models.RoleApplication{}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	servers, err := s.mcpRepo.ListServersByOwner(id)
+	if err != nil {
+		return nil, err
+	}
+	if servers == nil {
+		servers = []models.MCPServer{}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cards, err := s.mcpRepo.ListCardsByOwner(id)
+	if err != nil {
+		return nil, err
+	}
+	if cards == nil {
+		cards = []models.MCPCard{}
+	}
+
+	return &models.UserDataExport{
+		ExportedAt:           time.Now().UTC(),
+		Profile:              profile,
+		AuthMethods:          authMethods,
+		RewardAddressHistory: rewardHistory,
+		RoleApplications:     applications,
+		MCPServers:           servers,
+		MCPCards:             cards,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apitest"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+)
+
+func TestExportUserDataIncludesOwnedMCPResources(t *testing.T) {
+	store := apitest.NewMemoryStore()
+	users := services.NewUserService(store.UserRepository(), services.NewNonceService(), services.NewWeb3Service(), services.NewUserCache(time.Minute), services.NopAuthMetrics{}, time.Hour)
+	exports := services.NewExportService(store.UserRepository(), store.RewardAddressRepository(), store.RoleApplicationRepository(), store.MCPRepository())
+
+	ctx := context.Background()
+	alice, _, err := users.ProvisionWeb3User(ctx, "alice", "0x00000000000000000000000000000000000000a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, _, err := users.ProvisionWeb3User(ctx, "bob", "0x00000000000000000000000000000000000000b2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := store.AddMCPServer(models.MCPServer{Name: "weather", Image: "example/weather:1", OwnerID: &alice.UserID})
+	store.AddMCPServer(models.MCPServer{Name: "other", Image: "example/other:1", OwnerID: &bob.UserID})
+	name := "Weather"
+	card := store.AddMCPCard(models.MCPCard{Name: &name, GithubURL: "https://github.com/example/weather", OwnerID: &alice.UserID})
+	store.AddMCPCard(models.MCPCard{GithubURL: "https://github.com/example/other", OwnerID: &bob.UserID})
+
+	export, err := exports.ExportUserData(ctx, alice.UserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.MCPServers) != 1 || export.MCPServers[0].ID != server.ID {
+		t.Errorf("servers = %+v", export.MCPServers)
+	}
+	if len(export.MCPCards) != 1 || export.MCPCards[0].ID != card.ID {
+		t.Errorf("cards = %+v", export.MCPCards)
+	}
+
+	// 没有发布内容时导出空数组而不是null
+	export, err = exports.ExportUserData(ctx, bob.UserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.RewardAddressHistory == nil || export.RoleApplications == nil {
+		t.Errorf("export = %+v", export)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := exports.ExportUserData(cancelled, alice.UserID); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled export error = %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+)
+
+// MCPService MCP服务器和卡片的只读服务。
+// 部署和导入依赖Kubernetes与GitHub，Go版本尚未实现
+type MCPService struct {
+	mcpRepo repositories.MCPRepository
+}
+
+// NewMCPService 创建MCP服务
+func NewMCPService(mcpRepo repositories.MCPRepository) *MCPService {
+	return &MCPService{mcpRepo: mcpRepo}
+}
+
+// ListServers 列出所有MCP服务器，最新的在前
+func (s *MCPService) ListServers() ([]models.MCPServer, error) {
+	servers, err := s.mcpRepo.ListServers()
+	if err != nil {
+		return nil, err
+	}
+	if servers == nil {
+		servers = []models.MCPServer{}
+	}
+	return servers, nil
+}
+
+// GetServer 根据名称获取MCP服务器
+func (s *MCPService) GetServer(name string) (*models.MCPServer, error) {
+	server, err := s.mcpRepo.FindServerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, apperrors.ErrMCPServerNotFound
+	}
+	return server, nil
+}
+
+// ListCards 列出所有MCP卡片，最新的在前
+func (s *MCPService) ListCards() ([]models.MCPCard, error) {
+	cards, err := s.mcpRepo.ListCards()
+	if err != nil {
+		return nil, err
+	}
+	if cards == nil {
+		cards = []models.MCPCard{}
+	}
+	return cards, nil
+}
+
+// GetCard 根据ID获取MCP卡片
+func (s *MCPService) GetCard(id uint) (*models.MCPCard, error) {
+	card, err := s.mcpRepo.FindCardByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil {
+		return nil, apperrors.ErrMCPCardNotFound
+	}
+	return card, nil
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -9,10 +11,17 @@ import (
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
 )
 
+// nonceTTL 挑战的有效期
+const nonceTTL = 5 * time.Minute
+
+// probeNonceKey 健康检查使用的key，不是合法地址，不会与挑战冲突
+const probeNonceKey = "health-check"
+
 // NonceService 管理nonce的服务
 type NonceService struct {
 	store map[string]*models.NonceStore
 	mutex sync.RWMutex
+	now   func() time.Time
 }
 
 // NewNonceService 创建nonce服务
@@ -20,32 +29,62 @@ func NewNonceService() *NonceService {
 	return &NonceService{
 		store: make(map[string]*models.NonceStore),
 		mutex: sync.RWMutex{},
+		now:   time.Now,
 	}
 }
 
 // GenerateNonce 为地址生成nonce
 func (n *NonceService) GenerateNonce(address string) *models.NonceStore {
+	return n.GenerateNonceWithPrefix(address, "Login to MCPForge")
+}
+
+// GenerateNonceWithPrefix 以指定的消息前缀生成nonce，key区分不同用途的挑战
+func (n *NonceService) GenerateNonceWithPrefix(key, prefix string) *models.NonceStore {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
 
 	// 生成nonce
-	timestamp := time.Now().Format(time.RFC3339)
+	now := n.now()
+	timestamp := now.Format(time.RFC3339)
 	randomID := generateRandomString(15)
-	nonce := fmt.Sprintf("Login to MCPForge at %s with nonce: %s", timestamp, randomID)
-	expires := time.Now().Add(5 * time.Minute) // 5分钟过期
+	nonce := fmt.Sprintf("%s at %s with nonce: %s", prefix, timestamp, randomID)
+	expires := now.Add(nonceTTL)
 
 	nonceStore := &models.NonceStore{
 		Nonce:   nonce,
 		Expires: expires,
 	}
 
-	// 存储nonce (地址统一转为小写)
-	normalizedAddress := address
-	n.store[normalizedAddress] = nonceStore
+	// 按key存储nonce，登录挑战的key为小写地址
+	n.store[key] = nonceStore
 
 	return nonceStore
 }
 
+// Len 尚未过期的nonce数量，已过期但还未清理的不计入
+func (n *NonceService) Len() int {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	now := n.now()
+	count := 0
+	for _, nonceStore := range n.store {
+		if !nonceStore.Expires.Before(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// Probe 生成并消费一个nonce，确认存储可以读写且未被锁死
+func (n *NonceService) Probe() error {
+	nonceStore := n.GenerateNonceWithPrefix(probeNonceKey, "Health check")
+	if !n.VerifyAndConsumeNonce(probeNonceKey, nonceStore.Nonce) {
+		return errors.New("generated nonce was not accepted")
+	}
+	return nil
+}
+
 // VerifyAndConsumeNonce 验证并消费nonce
 func (n *NonceService) VerifyAndConsumeNonce(address, nonce string) bool {
 	n.mutex.Lock()
@@ -59,7 +98,7 @@ func (n *NonceService) VerifyAndConsumeNonce(address, nonce string) bool {
 	}
 
 	// 检查过期
-	if storedNonce.Expires.Before(time.Now()) {
+	if storedNonce.Expires.Before(n.now()) {
 		delete(n.store, normalizedAddress)
 		return false
 	}
@@ -74,15 +113,34 @@ func (n *NonceService) VerifyAndConsumeNonce(address, nonce string) bool {
 	return true
 }
 
-// CleanupExpired 清理过期的nonce
-func (n *NonceService) CleanupExpired() {
+// CleanupExpired 清理过期的nonce，返回清理的数量
+func (n *NonceService) CleanupExpired() int {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
 
-	now := time.Now()
+	now := n.now()
+	removed := 0
 	for address, nonceStore := range n.store {
 		if nonceStore.Expires.Before(now) {
 			delete(n.store, address)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RunCleanup 定期清理过期的nonce，直到ctx被取消。
+// 挑战无需登录即可申请，不清理时未完成的挑战会一直占用内存
+func (n *NonceService) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.CleanupExpired()
 		}
 	}
 }
@@ -95,4 +153,4 @@ func generateRandomString(length int) string {
 		b[i] = charset[rand.Intn(len(charset))]
 	}
 	return string(b)
-}
\ No newline at end of file
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestNonceService 返回时钟可由测试推进的nonce服务
+func newTestNonceService() (*NonceService, func(time.Duration)) {
+	n := NewNonceService()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+	return n, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestNonceServiceExpiry(t *testing.T) {
+	n, advance := newTestNonceService()
+	first := n.GenerateNonce("0xa1")
+	n.GenerateNonce("0xb2")
+	if got := n.Len(); got != 2 {
+		t.Fatalf("Len = %d, want 2", got)
+	}
+
+	advance(nonceTTL - time.Second)
+	n.GenerateNonce("0xc3")
+	if !n.VerifyAndConsumeNonce("0xa1", first.Nonce) {
+		t.Fatal("valid nonce rejected")
+	}
+	if n.VerifyAndConsumeNonce("0xa1", first.Nonce) {
+		t.Error("nonce accepted twice")
+	}
+
+	// 过期但未清理的挑战不计入
+	advance(2 * time.Second)
+	if got := n.Len(); got != 1 {
+		t.Errorf("Len after expiry = %d, want 1", got)
+	}
+	if removed := n.CleanupExpired(); removed != 1 {
+		t.Errorf("CleanupExpired removed %d, want 1", removed)
+	}
+	if got := len(n.store); got != 1 {
+		t.Errorf("%d entries stored after cleanup, want 1", got)
+	}
+}
+
+func TestNonceServiceRunCleanup(t *testing.T) {
+	n, advance := newTestNonceService()
+	n.GenerateNonce("0xa1")
+	advance(nonceTTL + time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		n.RunCleanup(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		n.mutex.RLock()
+		remaining := len(n.store)
+		n.mutex.RUnlock()
+		if remaining == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expired nonce not cleaned up")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestNonceServiceProbe(t *testing.T) {
+	n, _ := newTestNonceService()
+	if err := n.Probe(); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(n.store); got != 0 {
+		t.Errorf("probe left %d entries behind", got)
+	}
+}
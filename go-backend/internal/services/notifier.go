@@ -0,0 +1,161 @@
+package services
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// mailTimeout 单封邮件的发送时限，通知在请求中同步发送，不能长时间阻塞
+const mailTimeout = 10 * time.Second
+
+// errNoEmail 用户没有设置邮箱，无法发送通知
+var errNoEmail = errors.New("user has no email address")
+
+// Notifier 向用户发送账户相关的通知
+type Notifier interface {
+	Notify(user *models.User, subject, body string) error
+}
+
+// LogNotifier 只记录通知的用户和主题，用于未配置邮件的开发和测试环境。
+// 邮箱和正文属于个人数据，不写入日志
+type LogNotifier struct {
+	logger *logger.Logger
+}
+
+// NewLogNotifier 创建日志通知器
+func NewLogNotifier(l *logger.Logger) *LogNotifier {
+	return &LogNotifier{logger: l}
+}
+
+// Notify 记录通知的用户和主题
+func (n *LogNotifier) Notify(user *models.User, subject, _ string) error {
+	n.logger.Info("User notification", "user_id", user.UserID, "subject", subject)
+	return nil
+}
+
+// MailNotifier 通过SMTP向用户邮箱发送通知
+type MailNotifier struct {
+	cfg    config.MailConfig
+	logger *logger.Logger
+	send   func(from, to string, message []byte) error
+	now    func() time.Time
+}
+
+// NewMailNotifier 创建邮件通知器
+func NewMailNotifier(cfg config.MailConfig, l *logger.Logger) *MailNotifier {
+	n := &MailNotifier{cfg: cfg, logger: l, now: time.Now}
+	n.send = n.sendSMTP
+	return n
+}
+
+// Notify 发送邮件。调用方不处理通知失败，失败原因在这里记录
+func (n *MailNotifier) Notify(user *models.User, subject, body string) error {
+	err := n.notify(user, subject, body)
+	if err != nil {
+		n.logger.Warn("Failed to send user notification", "user_id", user.UserID, "subject", subject, "error", err.Error())
+	}
+	return err
+}
+
+func (n *MailNotifier) notify(user *models.User, subject, body string) error {
+	if user.Email == nil || *user.Email == "" {
+		return errNoEmail
+	}
+	to, err := mail.ParseAddress(*user.Email)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+	from, err := mail.ParseAddress(n.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	return n.send(from.Address, to.Address, n.message(from, to, subject, body))
+}
+
+// message 构造纯文本邮件，主题去掉换行以免注入邮件头
+func (n *MailNotifier) message(from, to *mail.Address, subject, body string) []byte {
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// sendSMTP 465端口使用TLS连接，其他端口在服务器支持时升级为STARTTLS
+func (n *MailNotifier) sendSMTP(from, to string, message []byte) error {
+	addr := net.JoinHostPort(n.cfg.Host, strconv.Itoa(n.cfg.Port))
+	dialer := &net.Dialer{Timeout: mailTimeout}
+	tlsConfig := &tls.Config{ServerName: n.cfg.Host}
+
+	var conn net.Conn
+	var err error
+	if n.cfg.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(mailTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if n.cfg.Port != 465 {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		}
+	}
+	if n.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// bufferLogger 将日志写入buf的记录器
+func bufferLogger(buf *bytes.Buffer) *logger.Logger {
+	return &logger.Logger{Logger: slog.New(slog.NewJSONHandler(buf, nil))}
+}
+
+func TestLogNotifierOmitsPersonalData(t *testing.T) {
+	var buf bytes.Buffer
+	email := "alice@example.com"
+	user := &models.User{UserID: 7, Email: &email}
+	if err := NewLogNotifier(bufferLogger(&buf)).Notify(user, "Reward address change scheduled", "new address 0xabc"); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"user_id":7`) || !strings.Contains(out, "Reward address change scheduled") {
+		t.Errorf("log = %s", out)
+	}
+	if strings.Contains(out, email) || strings.Contains(out, "0xabc") {
+		t.Errorf("log contains personal data: %s", out)
+	}
+}
+
+type sentMail struct {
+	from, to string
+	message  string
+}
+
+func newTestMailNotifier(buf *bytes.Buffer) (*MailNotifier, *[]sentMail) {
+	n := NewMailNotifier(config.MailConfig{Host: "smtp.example.com", Port: 587, From: "MCPForge <noreply@example.com>"}, bufferLogger(buf))
+	n.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	var sent []sentMail
+	n.send = func(from, to string, message []byte) error {
+		sent = append(sent, sentMail{from, to, string(message)})
+		return nil
+	}
+	return n, &sent
+}
+
+func TestMailNotifierMessage(t *testing.T) {
+	var buf bytes.Buffer
+	n, sent := newTestMailNotifier(&buf)
+	email := "alice@example.com"
+
+	err := n.Notify(&models.User{UserID: 7, Email: &email}, "Address change\r\nBcc: mallory@example.com", "line one\nline two")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*sent) != 1 {
+		t.Fatalf("sent %d messages", len(*sent))
+	}
+	got := (*sent)[0]
+	if got.from != "noreply@example.com" || got.to != email {
+		t.Errorf("envelope = %s -> %s", got.from, got.to)
+	}
+
+	headers, body, _ := strings.Cut(got.message, "\r\n\r\n")
+	for _, want := range []string{
+		`From: "MCPForge" <noreply@example.com>`,
+		"To: <alice@example.com>",
+		"Subject: Address change  Bcc: mallory@example.com",
+		"Date: Wed, 01 Jan 2025 00:00:00 +0000",
+		"Content-Type: text/plain; charset=utf-8",
+	} {
+		if !strings.Contains(headers, want+"\r\n") && !strings.HasSuffix(headers, want) {
+			t.Errorf("headers missing %q:\n%s", want, headers)
+		}
+	}
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Errorf("subject injected a header:\n%s", headers)
+	}
+	if body != "line one\r\nline two\r\n" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestMailNotifierWithoutEmail(t *testing.T) {
+	var buf bytes.Buffer
+	n, sent := newTestMailNotifier(&buf)
+	if err := n.Notify(&models.User{UserID: 7}, "subject", "body"); err == nil {
+		t.Error("notification without an email succeeded")
+	}
+	if len(*sent) != 0 {
+		t.Errorf("sent %d messages", len(*sent))
+	}
+	if !strings.Contains(buf.String(), `"user_id":7`) {
+		t.Errorf("failure not logged: %s", buf.String())
+	}
+}
+
+// fakeSMTPServer 接收一封邮件的最小SMTP服务器，返回收到的DATA内容
+func fakeSMTPServer(t *testing.T) (host string, port int, received <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				ch <- data.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, ch
+}
+
+func TestMailNotifierSMTP(t *testing.T) {
+	host, port, received := fakeSMTPServer(t)
+	var buf bytes.Buffer
+	n := NewMailNotifier(config.MailConfig{Host: host, Port: port, From: "noreply@example.com"}, bufferLogger(&buf))
+
+	email := "alice@example.com"
+	if err := n.Notify(&models.User{UserID: 7, Email: &email}, "Hello", "body text"); err != nil {
+		t.Fatalf("Notify: %v\n%s", err, buf.String())
+	}
+	select {
+	case data := <-received:
+		if !strings.Contains(data, "Subject: Hello\r\n") || !strings.Contains(data, "body text") {
+			t.Errorf("data = %q", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+}
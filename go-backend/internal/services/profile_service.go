@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+)
+
+// ProfileService 公开资料服务
+type ProfileService struct {
+	userRepo repositories.UserRepository
+	mcpRepo  repositories.MCPRepository
+}
+
+// NewProfileService 创建公开资料服务
+func NewProfileService(userRepo repositories.UserRepository, mcpRepo repositories.MCPRepository) *ProfileService {
+	return &ProfileService{
+		userRepo: userRepo,
+		mcpRepo:  mcpRepo,
+	}
+}
+
+// GetPublicProfile 根据用户名获取公开资料，已删除的账户视为不存在
+func (s *ProfileService) GetPublicProfile(username string) (*models.PublicProfile, error) {
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.DeletedAt.Valid {
+		return nil, apperrors.ErrProfileNotFound
+	}
+
+	servers, err := s.mcpRepo.ListServersByOwner(user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.mcpRepo.ListCardsByOwner(user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewPublicProfile(user, servers, cards), nil
+}
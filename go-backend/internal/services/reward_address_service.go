@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+// activateBatchSize 每轮激活的最大变更数
+const activateBatchSize = 100
+
+// RewardAddressService 收款地址变更服务：新地址签名证明所有权，冷静期后生效
+type RewardAddressService struct {
+	rewardRepo   repositories.RewardAddressRepository
+	userRepo     repositories.UserRepository
+	nonceService *NonceService
+	web3Service  *Web3Service
+	userCache    *UserCache
+	notifier     Notifier
+
+	// coolingPeriod 变更从验证到生效的等待时长
+	coolingPeriod time.Duration
+}
+
+// NewRewardAddressService 创建收款地址变更服务
+func NewRewardAddressService(rewardRepo repositories.RewardAddressRepository, userRepo repositories.UserRepository, nonceService *NonceService, web3Service *Web3Service, userCache *UserCache, notifier Notifier, coolingPeriod time.Duration) *RewardAddressService {
+	return &RewardAddressService{
+		rewardRepo:    rewardRepo,
+		userRepo:      userRepo,
+		nonceService:  nonceService,
+		web3Service:   web3Service,
+		userCache:     userCache,
+		notifier:      notifier,
+		coolingPeriod: coolingPeriod,
+	}
+}
+
+// rewardChallengeKey 收款地址挑战的nonce key，与登录挑战分开存储
+func rewardChallengeKey(userID uint, address string) string {
+	return fmt.Sprintf("reward:%d:%s", userID, address)
+}
+
+// GenerateChallenge 为新收款地址生成所有权挑战
+func (s *RewardAddressService) GenerateChallenge(userID uint, address string) (*models.Web3ChallengeResponse, error) {
+	if !s.web3Service.ValidateEthereumAddress(address) {
+		return nil, apperrors.ErrInvalidAddress
+	}
+
+	normalizedAddress := s.web3Service.NormalizeAddress(address)
+	prefix := fmt.Sprintf("Verify MCPForge reward address %s for user %d", normalizedAddress, userID)
+	nonceStore := s.nonceService.GenerateNonceWithPrefix(rewardChallengeKey(userID, normalizedAddress), prefix)
+
+	return &models.Web3ChallengeResponse{
+		Nonce:     nonceStore.Nonce,
+		ExpiresAt: nonceStore.Expires.Format(time.RFC3339),
+	}, nil
+}
+
+// RequestChange 验证新地址的签名并创建待生效的变更，之前未生效的变更被取消
+func (s *RewardAddressService) RequestChange(userID uint, req *models.RewardAddressChangeRequest) (*models.RewardAddressChange, error) {
+	if !s.web3Service.ValidateEthereumAddress(req.Address) {
+		return nil, apperrors.ErrInvalidAddress
+	}
+	normalizedAddress := s.web3Service.NormalizeAddress(req.Address)
+
+	if !s.nonceService.VerifyAndConsumeNonce(rewardChallengeKey(userID, normalizedAddress), req.Nonce) {
+		return nil, apperrors.ErrInvalidNonce
+	}
+	if !s.web3Service.VerifySignature(req.Nonce, req.Signature, req.Address) {
+		return nil, apperrors.ErrInvalidSignature
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.RewardAddress != nil && *user.RewardAddress == normalizedAddress {
+		return nil, apperrors.ErrRewardAddressUnchanged
+	}
+
+	change := &models.RewardAddressChange{
+		UserID:      userID,
+		Address:     normalizedAddress,
+		Status:      models.RewardAddressPending,
+		EffectiveAt: time.Now().Add(s.coolingPeriod),
+	}
+	if err := s.rewardRepo.ReplacePending(change); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateKey) {
+			// 并发提交了另一个变更
+			return nil, apperrors.ErrRewardChangeInProgress
+		}
+		return nil, err
+	}
+
+	s.notify(user, "Reward address change requested",
+		fmt.Sprintf("Your reward address will change to %s at %s. If you did not request this, cancel it before then.",
+			change.Address, change.EffectiveAt.UTC().Format(time.RFC3339)))
+
+	return change, nil
+}
+
+// Overview 返回当前收款地址、待生效变更和地址历史
+func (s *RewardAddressService) Overview(userID uint) (*models.RewardAddressOverview, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.History(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &models.RewardAddressOverview{
+		Current: user.RewardAddress,
+		History: history,
+	}
+	for i := range history {
+		if history[i].Status == models.RewardAddressPending {
+			overview.Pending = &history[i]
+			break
+		}
+	}
+	return overview, nil
+}
+
+// History 返回用户的收款地址变更历史
+func (s *RewardAddressService) History(userID uint) ([]models.RewardAddressChange, error) {
+	history, err := s.rewardRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if history == nil {
+		history = []models.RewardAddressChange{}
+	}
+	return history, nil
+}
+
+// CancelPending 取消冷静期内的变更
+func (s *RewardAddressService) CancelPending(userID uint) (*models.RewardAddressChange, error) {
+	change, err := s.rewardRepo.CancelPending(userID)
+	if err != nil {
+		return nil, err
+	}
+	if change == nil {
+		return nil, apperrors.ErrNoPendingRewardChange
+	}
+
+	if user, err := s.userRepo.FindByID(userID); err == nil {
+		s.notify(user, "Reward address change cancelled",
+			fmt.Sprintf("The pending change of your reward address to %s was cancelled.", change.Address))
+	}
+
+	return change, nil
+}
+
+// ActivateDue 激活冷静期已结束的变更，返回处理数量
+func (s *RewardAddressService) ActivateDue() (int, error) {
+	activated := 0
+	for {
+		changes, err := s.rewardRepo.FindDue(time.Now(), activateBatchSize)
+		if err != nil {
+			return activated, err
+		}
+
+		for i := range changes {
+			change := &changes[i]
+			if err := s.rewardRepo.Activate(change); err != nil {
+				return activated, err
+			}
+			s.userCache.Invalidate(change.UserID)
+			activated++
+
+			if user, err := s.userRepo.FindByID(change.UserID); err == nil {
+				s.notify(user, "Reward address changed",
+					fmt.Sprintf("Your reward address is now %s.", change.Address))
+			}
+		}
+
+		if len(changes) < activateBatchSize {
+			return activated, nil
+		}
+	}
+}
+
+// RunActivator 定期激活到期的收款地址变更，直到ctx被取消
+func (s *RewardAddressService) RunActivator(ctx context.Context, interval time.Duration, l *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		activated, err := s.ActivateDue()
+		if err != nil {
+			l.Error("Failed to activate reward address changes", "error", err.Error(), "activated", activated)
+		} else if activated > 0 {
+			l.Info("Activated reward address changes", "count", activated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// notify 通知失败不影响变更本身
+func (s *RewardAddressService) notify(user *models.User, subject, body string) {
+	_ = s.notifier.Notify(user, subject, body)
+}
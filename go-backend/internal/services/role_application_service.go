@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+)
+
+// githubHandlePattern GitHub用户名规则：字母数字和连字符，不能以连字符开头，最长39个字符
+var githubHandlePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]{0,38}$`)
+
+// RoleApplicationService 开发者角色申请与审核服务
+type RoleApplicationService struct {
+	applicationRepo repositories.RoleApplicationRepository
+	userRepo        repositories.UserRepository
+	userCache       *UserCache
+	notifier        Notifier
+}
+
+// NewRoleApplicationService 创建角色申请服务
+func NewRoleApplicationService(applicationRepo repositories.RoleApplicationRepository, userRepo repositories.UserRepository, userCache *UserCache, notifier Notifier) *RoleApplicationService {
+	return &RoleApplicationService{
+		applicationRepo: applicationRepo,
+		userRepo:        userRepo,
+		userCache:       userCache,
+		notifier:        notifier,
+	}
+}
+
+// Submit 提交开发者角色申请
+func (s *RoleApplicationService) Submit(userID uint, req *models.RoleApplicationRequest) (*models.RoleApplication, error) {
+	githubHandle := strings.TrimPrefix(strings.TrimSpace(req.GithubHandle), "@")
+	if !githubHandlePattern.MatchString(githubHandle) {
+		return nil, apperrors.Field("github_handle", "must be a valid GitHub username")
+	}
+
+	var servers []string
+	for _, server := range req.IntendedServers {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+	if len(servers) == 0 {
+		return nil, apperrors.Field("intended_servers", "at least one intended server is required")
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != models.UserRoleUser {
+		return nil, apperrors.ErrRoleAlreadyGranted.WithMessage("user already has the %s role", user.Role)
+	}
+
+	existing, err := s.applicationRepo.FindPendingByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.ErrRoleApplicationPending
+	}
+
+	application := &models.RoleApplication{
+		UserID:          userID,
+		RequestedRole:   models.UserRoleDeveloper,
+		DisplayName:     req.DisplayName,
+		Bio:             req.Bio,
+		GithubHandle:    githubHandle,
+		IntendedServers: servers,
+		Status:          models.RoleApplicationPending,
+	}
+	if err := s.applicationRepo.Create(application, userID); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateKey) {
+			// 并发提交了另一个申请
+			return nil, apperrors.ErrRoleApplicationPending
+		}
+		return nil, err
+	}
+
+	return application, nil
+}
+
+// ListMine 返回用户自己的申请历史
+func (s *RoleApplicationService) ListMine(userID uint) ([]models.RoleApplication, error) {
+	applications, err := s.applicationRepo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if applications == nil {
+		applications = []models.RoleApplication{}
+	}
+	return applications, nil
+}
+
+// List 按状态列出申请，供管理员审核
+func (s *RoleApplicationService) List(status *models.RoleApplicationStatus) ([]models.RoleApplication, error) {
+	applications, err := s.applicationRepo.List(status)
+	if err != nil {
+		return nil, err
+	}
+	if applications == nil {
+		applications = []models.RoleApplication{}
+	}
+	return applications, nil
+}
+
+// Get 获取申请及其审计事件
+func (s *RoleApplicationService) Get(id uint) (*models.RoleApplication, error) {
+	return s.applicationRepo.FindByID(id)
+}
+
+// Approve 通过申请并授予申请的角色
+func (s *RoleApplicationService) Approve(id, reviewerID uint, notes *string) (*models.RoleApplication, error) {
+	return s.review(id, reviewerID, notes, models.RoleApplicationApproved)
+}
+
+// Reject 拒绝申请，必须填写原因
+func (s *RoleApplicationService) Reject(id, reviewerID uint, notes *string) (*models.RoleApplication, error) {
+	if notes == nil || strings.TrimSpace(*notes) == "" {
+		return nil, apperrors.Field("notes", "notes are required when rejecting an application")
+	}
+	return s.review(id, reviewerID, notes, models.RoleApplicationRejected)
+}
+
+// review 记录审核结果并通知申请人
+func (s *RoleApplicationService) review(id, reviewerID uint, notes *string, status models.RoleApplicationStatus) (*models.RoleApplication, error) {
+	application, err := s.applicationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if application.Status != models.RoleApplicationPending {
+		return nil, apperrors.ErrRoleApplicationReviewed
+	}
+	if application.UserID == reviewerID {
+		return nil, apperrors.ErrSelfReview
+	}
+
+	now := time.Now()
+	application.Status = status
+	application.ReviewerID = &reviewerID
+	application.ReviewNotes = notes
+	application.ReviewedAt = &now
+
+	action := models.RoleApplicationReject
+	if status == models.RoleApplicationApproved {
+		action = models.RoleApplicationApprove
+	}
+	event := &models.RoleApplicationEvent{
+		ApplicationID: application.ID,
+		ActorID:       reviewerID,
+		Action:        action,
+		Notes:         notes,
+	}
+	if err := s.applicationRepo.Review(application, event); err != nil {
+		return nil, err
+	}
+	application.Events = append(application.Events, *event)
+
+	// 角色变更需要立即生效
+	s.userCache.Invalidate(application.UserID)
+
+	if user, err := s.userRepo.FindByID(application.UserID); err == nil {
+		body := fmt.Sprintf("Your developer application was %s.", status)
+		if notes != nil && *notes != "" {
+			body += " Reviewer notes: " + *notes
+		}
+		_ = s.notifier.Notify(user, "Developer application "+string(status), body)
+	}
+
+	return application, nil
+}
+
+// Provision 供运维命令直接设置用户角色，角色未变化时返回nil。
+// 变更同样记录为已审核的申请和审计事件：运维人员没有对应的账户，
+// 事件的actor记为账户本身，operator 描述执行者和命令，记录在notes中
+func (s *RoleApplicationService) Provision(userID uint, role models.UserRole, operator string) (*models.RoleApplication, error) {
+	if !role.IsValid() {
+		return nil, apperrors.Field("role", fmt.Sprintf("invalid value %q", role))
+	}
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == role {
+		return nil, nil
+	}
+
+	now := time.Now()
+	notes := fmt.Sprintf("role changed from %s to %s by %s", user.Role, role, operator)
+	application := &models.RoleApplication{
+		UserID:        userID,
+		RequestedRole: role,
+		Status:        models.RoleApplicationApproved,
+		ReviewNotes:   &notes,
+		ReviewedAt:    &now,
+	}
+	if user.GithubHandle != nil {
+		application.GithubHandle = *user.GithubHandle
+	}
+	event := &models.RoleApplicationEvent{
+		ActorID: userID,
+		Action:  models.RoleApplicationProvisioned,
+		Notes:   &notes,
+	}
+	if err := s.applicationRepo.Provision(application, event); err != nil {
+		return nil, err
+	}
+	application.Events = []models.RoleApplicationEvent{*event}
+
+	// 角色变更需要立即生效
+	s.userCache.Invalidate(userID)
+	return application, nil
+}
@@ -0,0 +1,65 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apitest"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+)
+
+func TestProvisionRecordsAuditEvent(t *testing.T) {
+	store := apitest.NewMemoryStore()
+	cache := services.NewUserCache(time.Minute)
+	users := services.NewUserService(store.UserRepository(), services.NewNonceService(), services.NewWeb3Service(), cache, services.NopAuthMetrics{}, time.Hour)
+	roles := services.NewRoleApplicationService(store.RoleApplicationRepository(), store.UserRepository(), cache, services.NewLogNotifier(logger.New("error")))
+
+	user, created, err := users.ProvisionWeb3User(context.Background(), "ops", "0x00000000000000000000000000000000000000c3")
+	if err != nil || !created || user.Role != models.UserRoleUser {
+		t.Fatalf("ProvisionWeb3User = %+v, %v, %v", user, created, err)
+	}
+	cache.Set(user)
+
+	application, err := roles.Provision(user.UserID, models.UserRoleAdmin, "root@host via mcpforge create-admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if application == nil || application.Status != models.RoleApplicationApproved || application.RequestedRole != models.UserRoleAdmin {
+		t.Fatalf("application = %+v", application)
+	}
+
+	// 审计事件和申请一起保存，并记录执行者
+	history, err := roles.ListMine(user.UserID)
+	if err != nil || len(history) != 1 || len(history[0].Events) != 1 {
+		t.Fatalf("history = %+v, %v", history, err)
+	}
+	event := history[0].Events[0]
+	if event.Action != models.RoleApplicationProvisioned || event.ActorID != user.UserID || event.Notes == nil ||
+		!strings.Contains(*event.Notes, "from user to admin by root@host via mcpforge create-admin") {
+		t.Errorf("event = %+v", event)
+	}
+
+	// 角色立即生效，缓存中的旧角色被清除
+	if _, ok := cache.Get(user.UserID); ok {
+		t.Error("cached user not invalidated")
+	}
+	if current, err := users.GetAuthUser(context.Background(), user.UserID); err != nil || current.Role != models.UserRoleAdmin {
+		t.Errorf("role = %v, %v", current, err)
+	}
+
+	// 角色相同时不重复记录
+	if again, err := roles.Provision(user.UserID, models.UserRoleAdmin, "ops"); err != nil || again != nil {
+		t.Errorf("repeated Provision = %+v, %v", again, err)
+	}
+	if history, _ := roles.ListMine(user.UserID); len(history) != 1 {
+		t.Errorf("%d applications after a no-op provision", len(history))
+	}
+
+	if _, err := roles.Provision(user.UserID, "owner", "ops"); err == nil {
+		t.Error("invalid role accepted")
+	}
+}
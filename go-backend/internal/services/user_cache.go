@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// userCacheEntry 用户缓存条目
+type userCacheEntry struct {
+	user    models.User
+	expires time.Time
+}
+
+// UserCache 认证用户的短期缓存，避免每个请求都查询数据库
+type UserCache struct {
+	ttl     time.Duration
+	entries map[uint]*userCacheEntry
+	mutex   sync.RWMutex
+	now     func() time.Time
+}
+
+// NewUserCache 创建用户缓存
+func NewUserCache(ttl time.Duration) *UserCache {
+	return &UserCache{
+		ttl:     ttl,
+		entries: make(map[uint]*userCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get 获取缓存的用户，过期或不存在时返回false，过期条目同时被删除
+func (c *UserCache) Get(id uint) (*models.User, bool) {
+	c.mutex.RLock()
+	entry, exists := c.entries[id]
+	c.mutex.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+	if entry.expires.Before(c.now()) {
+		c.mutex.Lock()
+		// 加写锁期间条目可能已被重新设置
+		if c.entries[id] == entry {
+			delete(c.entries, id)
+		}
+		c.mutex.Unlock()
+		return nil, false
+	}
+
+	// 返回副本，防止调用方修改缓存内容
+	user := entry.user
+	return &user, true
+}
+
+// Set 缓存用户
+func (c *UserCache) Set(user *models.User) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[user.UserID] = &userCacheEntry{
+		user:    *user,
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+// Invalidate 使用户缓存失效
+func (c *UserCache) Invalidate(id uint) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, id)
+}
+
+// Len 当前缓存的条目数，包括尚未清理的过期条目
+func (c *UserCache) Len() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.entries)
+}
+
+// CleanupExpired 删除所有过期条目，返回删除数量。
+// 只在 Get 时删除无法回收不再访问的用户，需要定期调用
+func (c *UserCache) CleanupExpired() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	removed := 0
+	for id, entry := range c.entries {
+		if entry.expires.Before(now) {
+			delete(c.entries, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RunCleanup 定期清理过期条目，直到ctx被取消
+func (c *UserCache) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CleanupExpired()
+		}
+	}
+}
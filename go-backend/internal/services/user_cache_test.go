@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// newTestCache 返回时钟可由测试推进的缓存
+func newTestCache(ttl time.Duration) (*UserCache, func(time.Duration)) {
+	c := NewUserCache(ttl)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestUserCacheExpiry(t *testing.T) {
+	c, advance := newTestCache(time.Minute)
+	c.Set(&models.User{UserID: 1, Username: "alice"})
+
+	user, ok := c.Get(1)
+	if !ok || user.Username != "alice" {
+		t.Fatalf("Get(1) = %+v, %v", user, ok)
+	}
+	// 返回的是副本
+	user.Username = "mallory"
+	if cached, _ := c.Get(1); cached.Username != "alice" {
+		t.Errorf("cache entry modified through returned user: %q", cached.Username)
+	}
+
+	advance(time.Minute + time.Second)
+	if _, ok := c.Get(1); ok {
+		t.Error("expired entry returned")
+	}
+	if n := c.Len(); n != 0 {
+		t.Errorf("expired entry not deleted on read, len = %d", n)
+	}
+}
+
+func TestUserCacheInvalidate(t *testing.T) {
+	c, _ := newTestCache(time.Minute)
+	c.Set(&models.User{UserID: 1})
+	c.Set(&models.User{UserID: 2})
+
+	c.Invalidate(1)
+	if _, ok := c.Get(1); ok {
+		t.Error("invalidated entry returned")
+	}
+	if _, ok := c.Get(2); !ok {
+		t.Error("other entry lost")
+	}
+	c.Invalidate(3)
+}
+
+func TestUserCacheCleanupExpired(t *testing.T) {
+	c, advance := newTestCache(time.Minute)
+	c.Set(&models.User{UserID: 1})
+	advance(30 * time.Second)
+	c.Set(&models.User{UserID: 2})
+	advance(45 * time.Second)
+
+	if removed := c.CleanupExpired(); removed != 1 {
+		t.Errorf("removed %d entries, want 1", removed)
+	}
+	if _, ok := c.Get(2); !ok || c.Len() != 1 {
+		t.Errorf("unexpired entry removed, len = %d", c.Len())
+	}
+}
+
+func TestUserCacheDisabled(t *testing.T) {
+	c, _ := newTestCache(0)
+	c.Set(&models.User{UserID: 1})
+	if _, ok := c.Get(1); ok || c.Len() != 0 {
+		t.Error("zero TTL should disable caching")
+	}
+}
+
+func TestUserCacheRunCleanup(t *testing.T) {
+	c := NewUserCache(time.Millisecond)
+	c.Set(&models.User{UserID: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.RunCleanup(ctx, 5*time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for c.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+	if c.Len() != 0 {
+		t.Error("RunCleanup did not remove the expired entry")
+	}
+}
@@ -1,35 +1,54 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"time"
 
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
 	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/tracing"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// registerRetries 注册遇到唯一约束冲突时的最大尝试次数
+const registerRetries = 3
+
 // UserService 用户业务逻辑服务
 type UserService struct {
 	userRepo     repositories.UserRepository
 	nonceService *NonceService
 	web3Service  *Web3Service
+	userCache    *UserCache
+	authMetrics  AuthMetrics
+
+	// deletionGracePeriod 删除后可恢复账户的时长
+	deletionGracePeriod time.Duration
 }
 
 // NewUserService 创建用户服务
-func NewUserService(userRepo repositories.UserRepository, nonceService *NonceService, web3Service *Web3Service) *UserService {
+func NewUserService(userRepo repositories.UserRepository, nonceService *NonceService, web3Service *Web3Service, userCache *UserCache, authMetrics AuthMetrics, deletionGracePeriod time.Duration) *UserService {
 	return &UserService{
-		userRepo:     userRepo,
-		nonceService: nonceService,
-		web3Service:  web3Service,
+		userRepo:            userRepo,
+		nonceService:        nonceService,
+		web3Service:         web3Service,
+		userCache:           userCache,
+		authMetrics:         authMetrics,
+		deletionGracePeriod: deletionGracePeriod,
 	}
 }
 
 // GenerateWeb3Challenge 生成Web3认证挑战
-func (s *UserService) GenerateWeb3Challenge(address string) (*models.Web3ChallengeResponse, error) {
+func (s *UserService) GenerateWeb3Challenge(ctx context.Context, address string) (resp *models.Web3ChallengeResponse, err error) {
+	_, span := tracing.Start(ctx, "UserService.GenerateWeb3Challenge")
+	defer func() { tracing.End(span, err) }()
+
 	// 验证地址格式
 	if !s.web3Service.ValidateEthereumAddress(address) {
-		return nil, errors.New("invalid ethereum address")
+		return nil, apperrors.ErrInvalidAddress
 	}
 
 	// 标准化地址
@@ -37,6 +56,7 @@ func (s *UserService) GenerateWeb3Challenge(address string) (*models.Web3Challen
 
 	// 生成nonce
 	nonceStore := s.nonceService.GenerateNonce(normalizedAddress)
+	s.authMetrics.ChallengeIssued()
 
 	return &models.Web3ChallengeResponse{
 		Nonce:     nonceStore.Nonce,
@@ -44,76 +64,52 @@ func (s *UserService) GenerateWeb3Challenge(address string) (*models.Web3Challen
 	}, nil
 }
 
-// VerifyWeb3Auth 验证Web3认证并登录/注册用户
-func (s *UserService) VerifyWeb3Auth(req *models.Web3AuthRequest) (*models.Web3AuthResponse, error) {
+// VerifyWeb3Auth 验证Web3认证并登录/注册用户，按结果记录认证指标
+func (s *UserService) VerifyWeb3Auth(ctx context.Context, req *models.Web3AuthRequest) (resp *models.Web3AuthResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyWeb3Auth")
+	defer func() { tracing.End(span, err) }()
+
+	resp, err = s.verifyWeb3Auth(ctx, req)
+	if err != nil {
+		s.authMetrics.AuthFailed(string(apperrors.From(err).Code))
+		return nil, err
+	}
+	s.authMetrics.AuthSucceeded(resp.Action)
+	span.SetAttributes(attribute.String("auth.action", resp.Action), attribute.Int("user.id", int(resp.User.UserID)))
+	return resp, nil
+}
+
+func (s *UserService) verifyWeb3Auth(ctx context.Context, req *models.Web3AuthRequest) (*models.Web3AuthResponse, error) {
 	// 标准化地址
 	normalizedAddress := strings.ToLower(req.Address)
 
 	// 1. 验证nonce
 	if !s.nonceService.VerifyAndConsumeNonce(normalizedAddress, req.Nonce) {
-		return nil, errors.New("invalid or expired nonce")
+		return nil, apperrors.ErrInvalidNonce
 	}
 
 	// 2. 验证签名
 	if !s.web3Service.VerifySignature(req.Nonce, req.Signature, req.Address) {
-		return nil, errors.New("invalid signature")
-	}
-
-	// 3. 查找或创建用户
-	user, err := s.userRepo.FindByAuthMethod(models.AuthTypeWeb3, normalizedAddress)
-	if err != nil {
-		return nil, err
+		return nil, apperrors.ErrInvalidSignature
 	}
 
+	// 3. 查找或创建用户，并发首次登录导致唯一约束冲突时重试
+	var user *models.User
 	var action string
-	if user == nil {
-		// 新用户注册
-		action = "register"
-		
-		// 如果没有提供用户名，使用地址作为用户名
-		username := normalizedAddress
-		if req.Username != nil && *req.Username != "" {
-			username = *req.Username
-		}
-
-		// 设置默认角色
-		role := models.UserRoleUser
-		if req.Role != nil {
-			role = *req.Role
-		}
-
-		// 创建新用户
-		newUser := &models.User{
-			Username:      username,
-			Email:         req.Email,
-			Role:          role,
-			RewardAddress: req.RewardAddress,
-		}
-
-		err = s.userRepo.Create(newUser)
-		if err != nil {
-			return nil, err
-		}
-
-		// 创建认证方法
-		authMethod := &models.AuthMethod{
-			UserID:         newUser.UserID,
-			AuthType:       models.AuthTypeWeb3,
-			AuthIdentifier: normalizedAddress,
-		}
-
-		err = s.userRepo.CreateAuthMethod(authMethod)
-		if err != nil {
-			return nil, err
+	var err error
+	for attempt := 0; attempt < registerRetries; attempt++ {
+		user, action, err = s.findOrRegisterWeb3User(ctx, req, normalizedAddress)
+		if !errors.Is(err, repositories.ErrDuplicateKey) {
+			break
 		}
-
-		user = newUser
-	} else {
-		action = "login"
+		logger.FromContext(ctx).Debug("Concurrent wallet registration detected", "attempt", attempt+1)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	// 重新查询用户以获取完整信息（包括AuthMethods）
-	fullUser, err := s.userRepo.FindByID(user.UserID)
+	fullUser, err := s.userRepo.WithContext(ctx).FindByID(user.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +117,8 @@ func (s *UserService) VerifyWeb3Auth(req *models.Web3AuthRequest) (*models.Web3A
 	message := "Web3 authentication successful"
 	if action == "register" {
 		message = "User registered and authenticated successfully"
+	} else if action == "restore" {
+		message = "Account restored and authenticated successfully"
 	}
 
 	return &models.Web3AuthResponse{
@@ -131,44 +129,109 @@ func (s *UserService) VerifyWeb3Auth(req *models.Web3AuthRequest) (*models.Web3A
 	}, nil
 }
 
-// CreateUser 创建用户
-func (s *UserService) CreateUser(req *models.CreateUserRequest) (*models.User, error) {
-	// 检查认证方法是否已存在
-	existingUser, err := s.userRepo.FindByAuthMethod(req.AuthType, req.AuthIdentifier)
+// findOrRegisterWeb3User 查找钱包地址对应的用户，不存在时在事务中注册
+func (s *UserService) findOrRegisterWeb3User(ctx context.Context, req *models.Web3AuthRequest, normalizedAddress string) (*models.User, string, error) {
+	repo := s.userRepo.WithContext(ctx)
+	user, err := repo.FindByAuthMethod(models.AuthTypeWeb3, normalizedAddress)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	if user != nil {
+		return user, "login", nil
+	}
+
+	// 账户处于删除宽限期内时只能恢复，不能重新注册
+	deletedUser, err := repo.FindDeletedByAuthMethod(models.AuthTypeWeb3, normalizedAddress)
+	if err != nil {
+		return nil, "", err
+	}
+	if deletedUser != nil {
+		if !req.Restore {
+			return nil, "", apperrors.ErrAccountPendingDeletion
+		}
+		if err := s.RestoreUser(ctx, deletedUser); err != nil {
+			return nil, "", err
+		}
+		return deletedUser, "restore", nil
+	}
+
+	// 新用户注册
+	// 如果没有提供用户名，使用地址作为用户名
+	username := normalizedAddress
+	if req.Username != nil && *req.Username != "" {
+		username = *req.Username
+	}
+	// 用户名被其他账户占用时直接拒绝，避免唯一约束冲突被当作并发注册重试
+	existingUser, err := repo.FindByUsername(username)
+	if err != nil {
+		return nil, "", err
 	}
 	if existingUser != nil {
-		return nil, errors.New("auth method already exists")
+		return nil, "", apperrors.ErrUsernameTaken
 	}
 
-	// 设置默认角色
-	role := models.UserRoleUser
-	if req.Role != nil {
-		role = *req.Role
+	// 新用户一律为普通用户，开发者角色需要提交申请并经过审核
+	newUser := &models.User{
+		Username: username,
+		Email:    req.Email,
+		Role:     models.UserRoleUser,
 	}
 
-	// 创建用户
-	user := &models.User{
-		Username:      req.Username,
-		Email:         req.Email,
-		Role:          role,
-		RewardAddress: req.RewardAddress,
+	if err := s.createUserWithAuthMethod(ctx, newUser, models.AuthTypeWeb3, normalizedAddress); err != nil {
+		return nil, "", err
 	}
 
-	err = s.userRepo.Create(user)
+	return newUser, "register", nil
+}
+
+// createUserWithAuthMethod 在同一事务中创建用户及其认证方法
+func (s *UserService) createUserWithAuthMethod(ctx context.Context, user *models.User, authType models.AuthType, authIdentifier string) error {
+	return s.userRepo.WithContext(ctx).WithTx(func(repo repositories.UserRepository) error {
+		if err := repo.Create(user); err != nil {
+			return err
+		}
+
+		return repo.CreateAuthMethod(&models.AuthMethod{
+			UserID:         user.UserID,
+			AuthType:       authType,
+			AuthIdentifier: authIdentifier,
+		})
+	})
+}
+
+// CreateUser 创建用户
+func (s *UserService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser", attribute.String("auth.type", string(req.AuthType)))
+	defer func() { tracing.End(span, err) }()
+
+	repo := s.userRepo.WithContext(ctx)
+	// 检查认证方法是否已存在
+	existingUser, err := repo.FindByAuthMethod(req.AuthType, req.AuthIdentifier)
 	if err != nil {
 		return nil, err
 	}
+	if existingUser == nil {
+		existingUser, err = repo.FindDeletedByAuthMethod(req.AuthType, req.AuthIdentifier)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if existingUser != nil {
+		return nil, apperrors.ErrAuthMethodExists
+	}
 
-	// 创建认证方法
-	authMethod := &models.AuthMethod{
-		UserID:         user.UserID,
-		AuthType:       req.AuthType,
-		AuthIdentifier: req.AuthIdentifier,
+	// 创建用户
+	user = &models.User{
+		Username: req.Username,
+		Email:    req.Email,
+		Role:     models.UserRoleUser,
 	}
 
-	err = s.userRepo.CreateAuthMethod(authMethod)
+	err = s.createUserWithAuthMethod(ctx, user, req.AuthType, req.AuthIdentifier)
+	if errors.Is(err, repositories.ErrDuplicateKey) {
+		// 并发创建了相同的认证方法
+		return nil, apperrors.ErrAuthMethodExists
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -176,32 +239,105 @@ func (s *UserService) CreateUser(req *models.CreateUserRequest) (*models.User, e
 	return user, nil
 }
 
+// ProvisionWeb3User 供运维命令使用：按钱包地址查找用户，不存在时创建普通用户。
+// 返回的bool表示是否新建了用户。角色通过 RoleApplicationService.Provision 设置，以留下审计记录
+func (s *UserService) ProvisionWeb3User(ctx context.Context, username, address string) (user *models.User, created bool, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ProvisionWeb3User")
+	defer func() { tracing.End(span, err) }()
+
+	if !s.web3Service.ValidateEthereumAddress(address) {
+		return nil, false, apperrors.ErrInvalidAddress
+	}
+	address = s.web3Service.NormalizeAddress(address)
+
+	repo := s.userRepo.WithContext(ctx)
+	user, err = repo.FindByAuthMethod(models.AuthTypeWeb3, address)
+	if err != nil {
+		return nil, false, err
+	}
+	if user != nil {
+		return user, false, nil
+	}
+
+	user = &models.User{
+		Username: username,
+		Role:     models.UserRoleUser,
+	}
+	err = s.createUserWithAuthMethod(ctx, user, models.AuthTypeWeb3, address)
+	if errors.Is(err, repositories.ErrDuplicateKey) {
+		return nil, false, apperrors.ErrUsernameTaken
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
 // GetUserByID 根据ID获取用户
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
-	return s.userRepo.FindByID(id)
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByID", attribute.Int("user.id", int(id)))
+	defer func() { tracing.End(span, err) }()
+
+	return s.userRepo.WithContext(ctx).FindByID(id)
+}
+
+// GetAuthUser 获取认证用户的最新信息，优先读取缓存
+func (s *UserService) GetAuthUser(ctx context.Context, id uint) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAuthUser", attribute.Int("user.id", int(id)))
+	defer func() { tracing.End(span, err) }()
+
+	if user, ok := s.userCache.Get(id); ok {
+		span.SetAttributes(attribute.Bool("cache.hit", true))
+		return user, nil
+	}
+	span.SetAttributes(attribute.Bool("cache.hit", false))
+
+	user, err = s.userRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.userCache.Set(user)
+	return user, nil
 }
 
-// GetAllUsers 获取所有用户
-func (s *UserService) GetAllUsers() ([]models.User, error) {
-	return s.userRepo.FindAll()
+// ListUsers 分页查询用户
+func (s *UserService) ListUsers(ctx context.Context, query *models.UserListQuery) (page *models.UserPage, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ListUsers")
+	defer func() { tracing.End(span, err) }()
+
+	return s.userRepo.WithContext(ctx).List(query)
 }
 
 // UpdateUser 更新用户信息
-func (s *UserService) UpdateUser(id uint, req *models.UpdateUserRequest) (*models.User, error) {
+func (s *UserService) UpdateUser(ctx context.Context, id uint, req *models.UpdateUserRequest) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser", attribute.Int("user.id", int(id)))
+	defer func() { tracing.End(span, err) }()
+
+	// 收款地址需要新地址签名证明所有权，只能通过收款地址变更流程修改
+	if req.RewardAddress != nil {
+		return nil, apperrors.ErrRewardAddressReadOnly
+	}
+	// 角色只能通过审核开发者申请变更
+	if req.Role != nil {
+		return nil, apperrors.ErrRoleReadOnly
+	}
+
+	repo := s.userRepo.WithContext(ctx)
 	// 查找用户
-	user, err := s.userRepo.FindByID(id)
+	user, err = repo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
 	// 检查用户名是否被其他用户使用
 	if req.Username != nil {
-		existingUser, err := s.userRepo.FindByUsername(*req.Username)
+		existingUser, err := repo.FindByUsername(*req.Username)
 		if err != nil {
 			return nil, err
 		}
 		if existingUser != nil && existingUser.UserID != id {
-			return nil, errors.New("username already exists")
+			return nil, apperrors.ErrUsernameTaken
 		}
 		user.Username = *req.Username
 	}
@@ -210,29 +346,52 @@ func (s *UserService) UpdateUser(id uint, req *models.UpdateUserRequest) (*model
 	if req.Email != nil {
 		user.Email = req.Email
 	}
-	if req.Role != nil {
-		user.Role = *req.Role
+	if req.DisplayName != nil {
+		user.DisplayName = req.DisplayName
 	}
-	if req.RewardAddress != nil {
-		user.RewardAddress = req.RewardAddress
+	if req.AvatarURL != nil {
+		user.AvatarURL = req.AvatarURL
+	}
+	if req.Bio != nil {
+		user.Bio = req.Bio
+	}
+	if req.Links != nil {
+		user.Links = req.Links
 	}
 
 	// 保存更新
-	err = s.userRepo.Update(user)
+	err = repo.Update(user)
+	if errors.Is(err, repositories.ErrDuplicateKey) {
+		// 检查之后其他用户并发占用了该用户名
+		return nil, apperrors.ErrUsernameTaken
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// 用户名变更需要立即生效
+	s.userCache.Invalidate(id)
+
 	return user, nil
 }
 
-// DeleteUser 删除用户
-func (s *UserService) DeleteUser(id uint) error {
+// DeleteUser 软删除用户，宽限期内可通过钱包登录恢复
+func (s *UserService) DeleteUser(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser", attribute.Int("user.id", int(id)))
+	defer func() { tracing.End(span, err) }()
+
+	repo := s.userRepo.WithContext(ctx)
 	// 检查用户是否存在
-	_, err := s.userRepo.FindByID(id)
+	_, err = repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	err = repo.Delete(id)
 	if err != nil {
 		return err
 	}
 
-	return s.userRepo.Delete(id)
-}
\ No newline at end of file
+	s.userCache.Invalidate(id)
+	return nil
+}
@@ -0,0 +1,51 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apitest"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/repositories"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+)
+
+// racingUserRepo 用户名检查时看不到其他用户，模拟检查与保存之间用户名被并发占用
+type racingUserRepo struct {
+	repositories.UserRepository
+}
+
+func (r racingUserRepo) WithContext(ctx context.Context) repositories.UserRepository {
+	return racingUserRepo{r.UserRepository.WithContext(ctx)}
+}
+
+func (racingUserRepo) FindByUsername(string) (*models.User, error) {
+	return nil, nil
+}
+
+func TestUpdateUserUsernameRace(t *testing.T) {
+	repo := racingUserRepo{apitest.NewMemoryStore().UserRepository()}
+	svc := services.NewUserService(repo, services.NewNonceService(), services.NewWeb3Service(), services.NewUserCache(time.Minute), services.NopAuthMetrics{}, time.Hour)
+
+	ctx := context.Background()
+	if _, _, err := svc.ProvisionWeb3User(ctx, "alice", "0x00000000000000000000000000000000000000a1"); err != nil {
+		t.Fatal(err)
+	}
+	bob, _, err := svc.ProvisionWeb3User(ctx, "bob", "0x00000000000000000000000000000000000000b2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	taken := "alice"
+	_, err = svc.UpdateUser(ctx, bob.UserID, &models.UpdateUserRequest{Username: &taken})
+	if !errors.Is(err, apperrors.ErrUsernameTaken) {
+		t.Fatalf("UpdateUser error = %v, want %v", err, apperrors.ErrUsernameTaken)
+	}
+	if status := apperrors.From(err).Status; status != http.StatusConflict {
+		t.Errorf("status = %d, want 409", status)
+	}
+}
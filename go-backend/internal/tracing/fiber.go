@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/reqctx"
+)
+
+// Middleware 为每个请求创建server span，并从请求头中提取上游的trace上下文。
+// 需注册在路由之前，处理器通过 reqctx.From 取得携带span的上下文
+func Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier{c})
+		// fasthttp会复用请求缓冲区，span在请求结束后才导出，字符串需要复制
+		ctx, span := Tracer().Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Method()),
+				attribute.String("url.path", strings.Clone(c.Path())),
+				attribute.String("client.address", strings.Clone(c.IP())),
+				attribute.String("user_agent.original", strings.Clone(c.Get(fiber.HeaderUserAgent))),
+			),
+		)
+		defer span.End()
+		reqctx.Set(c, ctx)
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// 错误尚未经过ErrorHandler，按其映射规则取状态码
+			status = apperrors.From(err).Status
+		}
+		// 路由模板在匹配后才确定，未匹配的请求只保留方法名，避免span名称随路径变化
+		var fiberErr *fiber.Error
+		if route := c.Route().Path; !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusNotFound {
+			span.SetName(c.Method() + " " + route)
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, strconv.Itoa(status))
+			if err != nil {
+				span.RecordError(err)
+			}
+		}
+		return err
+	}
+}
+
+// headerCarrier 让传播器读取Fiber请求头
+type headerCarrier struct {
+	c fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+// Set 只用于提取，不修改请求头
+func (h headerCarrier) Set(string, string) {}
+
+func (h headerCarrier) Keys() []string {
+	headers := h.c.GetReqHeaders()
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	return keys
+}
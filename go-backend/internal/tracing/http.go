@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPClient 出站HTTP客户端，为每次调用创建client span并注入traceparent请求头。
+// 目前只有健康检查使用；GitHub、Kubernetes尚未接入（相关兼容路由返回501），
+// 接入时应使用此客户端
+var HTTPClient = &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+// transport 追踪出站请求的RoundTripper
+type transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport 包装base，为经过的请求创建client span。
+// span只记录方法和主机，RPC地址的路径和查询参数中可能带有API密钥
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Tracer().Start(req.Context(), req.Method+" "+req.URL.Hostname(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	// RoundTripper不能修改调用方的请求，注入请求头前先复制
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "request failed")
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode))
+	}
+	return resp, nil
+}
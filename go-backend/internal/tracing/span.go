@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+)
+
+// Start 以ctx中的span为父span创建内部span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End 结束span并记录错误。只有映射为5xx的错误才将span标记为失败，
+// 参数校验、权限等业务错误只作为事件记录
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if apperrors.From(err).Status >= 500 {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
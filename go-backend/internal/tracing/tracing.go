@@ -0,0 +1,114 @@
+// Package tracing 配置OpenTelemetry链路追踪，并为HTTP请求、数据库查询和出站调用创建span
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/buildinfo"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+)
+
+// instrumentationName 本服务创建的span所属的instrumentation scope
+const instrumentationName = "github.com/YoubetDao/MCPForge-Backend/go-backend"
+
+// ShutdownFunc 导出剩余span并关闭追踪
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup 按配置安装全局TracerProvider和W3C trace-context传播器。
+// Exporter 为 none 时不导出span，但仍会传播上游的trace上下文
+func Setup(ctx context.Context, cfg config.TracingConfig, env string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if cfg.Exporter == config.TraceExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closeOutput, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	build := buildinfo.Get()
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+		attribute.String("service.version", build.Version),
+		attribute.String("deployment.environment.name", env),
+	))
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("build trace resource: %w", err), closeOutput())
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// 上游已决定采样时沿用其决定，只对新的trace按比例采样
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closeOutput())
+	}, nil
+}
+
+// newExporter 创建span导出器，返回的关闭函数用于释放导出文件
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+
+	switch cfg.Exporter {
+	case config.TraceExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, noClose, err
+	case config.TraceExporterFile:
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return exporter, f.Close, nil
+	case config.TraceExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(parseHeaders(cfg.Headers)))
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		return exporter, noClose, err
+	default:
+		return nil, nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+}
+
+// parseHeaders 解析 key=value 形式的请求头
+func parseHeaders(pairs []string) map[string]string {
+	headers := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if key, value, ok := strings.Cut(pair, "="); ok {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return headers
+}
+
+// Tracer 返回本服务使用的tracer，在Setup之前获取也会使用之后安装的provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
@@ -0,0 +1,239 @@
+// Package validation 根据DTO上的 validate 标签校验请求，
+// 作为Fiber的StructValidator在绑定请求体后自动执行
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/utils"
+)
+
+// Enum 枚举类型实现该接口后可使用 enum 规则校验取值
+type Enum interface {
+	IsValid() bool
+}
+
+var enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// Validator 支持的规则：
+//
+//	required     字段必须存在且非零值
+//	omitempty    零值（包括指向零值的指针）时跳过其余规则
+//	min=N,max=N  字符串按字符数，切片按元素数
+//	email        邮箱格式
+//	eth_address  以太坊地址
+//	url          http或https链接
+//	enum         取值必须满足 IsValid()
+//	oneof=a b    取值必须是列出的值之一
+//
+// 结构体和结构体切片字段会递归校验，字段名取自json标签
+type Validator struct{}
+
+// New 创建校验器
+func New() *Validator {
+	return &Validator{}
+}
+
+// Validate 校验结构体，返回包含全部字段错误的 apperrors.Error
+func (v *Validator) Validate(out any) error {
+	value := reflect.ValueOf(out)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var details []apperrors.FieldError
+	validateStruct(value, "", &details)
+	if len(details) > 0 {
+		return apperrors.Validation(details...)
+	}
+	return nil
+}
+
+// validateStruct 校验结构体的每个导出字段
+func validateStruct(value reflect.Value, prefix string, details *[]apperrors.FieldError) {
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "-" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		validateField(value.Field(i), field.Tag.Get("validate"), path, details)
+	}
+}
+
+// validateField 按标签规则校验单个字段，之后递归校验嵌套结构
+func validateField(value reflect.Value, tag, path string, details *[]apperrors.FieldError) {
+	rules := parseRules(tag)
+
+	if isZero(value) {
+		if _, ok := rules["required"]; ok {
+			*details = append(*details, apperrors.FieldError{Field: path, Message: "is required"})
+		}
+		return
+	}
+
+	// 指针字段校验指向的值
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	// omitempty 允许显式提交空值，例如用空字符串清除头像
+	if _, ok := rules["omitempty"]; ok && value.IsZero() {
+		return
+	}
+
+	for _, rule := range ruleOrder {
+		param, ok := rules[rule]
+		if !ok {
+			continue
+		}
+		if message := check(rule, param, value); message != "" {
+			*details = append(*details, apperrors.FieldError{Field: path, Message: message})
+			// 同一字段只报告第一个错误
+			return
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		validateStruct(value, path, details)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			elem := value.Index(i)
+			for elem.Kind() == reflect.Pointer && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct {
+				validateStruct(elem, fmt.Sprintf("%s[%d]", path, i), details)
+			}
+		}
+	}
+}
+
+// ruleOrder 规则的检查顺序，保证错误信息稳定
+var ruleOrder = []string{"min", "max", "email", "eth_address", "url", "enum", "oneof"}
+
+// check 执行单条规则，返回空字符串表示通过
+func check(rule, param string, value reflect.Value) string {
+	switch rule {
+	case "min", "max":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			panic(fmt.Sprintf("validation: invalid %s parameter %q", rule, param))
+		}
+		n, unit := length(value)
+		if n < 0 {
+			return ""
+		}
+		if rule == "min" && n < limit {
+			return fmt.Sprintf("must be at least %d %s", limit, unit)
+		}
+		if rule == "max" && n > limit {
+			return fmt.Sprintf("must be at most %d %s", limit, unit)
+		}
+	case "email":
+		if value.Kind() == reflect.String && !utils.IsValidEmail(value.String()) {
+			return "must be a valid email address"
+		}
+	case "eth_address":
+		if value.Kind() == reflect.String && !common.IsHexAddress(value.String()) {
+			return "must be a valid ethereum address"
+		}
+	case "url":
+		if value.Kind() == reflect.String && !IsWebURL(value.String()) {
+			return "must be an http or https URL"
+		}
+	case "enum":
+		if value.Type().Implements(enumType) && !value.Interface().(Enum).IsValid() {
+			return fmt.Sprintf("invalid value %q", fmt.Sprint(value.Interface()))
+		}
+	case "oneof":
+		allowed := strings.Fields(param)
+		current := fmt.Sprint(value.Interface())
+		for _, option := range allowed {
+			if option == current {
+				return ""
+			}
+		}
+		return "must be one of " + strings.Join(allowed, ", ")
+	}
+	return ""
+}
+
+// length 返回字符串的字符数或集合的元素数，其他类型返回-1
+func length(value reflect.Value) (int, string) {
+	switch value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(value.String()), "characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return value.Len(), "items"
+	}
+	return -1, ""
+}
+
+// IsWebURL 只接受带主机名的http(s)链接，避免javascript:等协议
+func IsWebURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// parseRules 解析 "required,min=3,max=50" 形式的标签
+func parseRules(tag string) map[string]string {
+	rules := make(map[string]string)
+	if tag == "" {
+		return rules
+	}
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			rules[name] = param
+		}
+	}
+	return rules
+}
+
+// isZero nil指针、空字符串和空切片视为未提供；指向零值的指针视为已提供
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+// fieldName 返回json标签中的字段名
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,192 @@
+package validation
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// 测试用的DTO，覆盖各条规则。json:"-" 和未导出的字段不校验，
+// 因此 valid() 不设置它们也能通过
+
+type basicRequest struct {
+	Name    string   `json:"name" validate:"required,min=3,max=5"`
+	Email   *string  `json:"email,omitempty" validate:"omitempty,email"`
+	Address string   `json:"address,omitempty" validate:"omitempty,eth_address"`
+	Website *string  `json:"website,omitempty" validate:"omitempty,url"`
+	Level   string   `json:"level,omitempty" validate:"omitempty,oneof=debug info"`
+	Tags    []string `json:"tags,omitempty" validate:"omitempty,max=2"`
+	Ignored string   `json:"-" validate:"required"`
+	hidden  string   `validate:"required"`
+}
+
+type enumRequest struct {
+	Role     *models.UserRole `json:"role,omitempty" validate:"omitempty,enum"`
+	AuthType models.AuthType  `json:"auth_type" validate:"required,enum"`
+}
+
+type nestedRequest struct {
+	Owner *basicRequest         `json:"owner" validate:"required"`
+	Links []models.ProfileLink  `json:"links,omitempty" validate:"max=3"`
+	Extra []*models.ProfileLink `json:"extra,omitempty"`
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// valid 满足全部规则的基础请求
+func valid() basicRequest {
+	return basicRequest{Name: "alice"}
+}
+
+// details 校验并返回字段错误，通过时返回nil
+func details(t *testing.T, in any) []apperrors.FieldError {
+	t.Helper()
+	err := New().Validate(in)
+	if err == nil {
+		return nil
+	}
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || !errors.Is(err, apperrors.ErrValidation) {
+		t.Fatalf("Validate returned %T %v, want a validation error", err, err)
+	}
+	return appErr.Details
+}
+
+func TestValidateRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *basicRequest)
+		field  string // 为空表示应通过
+		msg    string
+	}{
+		{"valid", func(r *basicRequest) {}, "", ""},
+		{"required missing", func(r *basicRequest) { r.Name = "" }, "name", "is required"},
+		{"too short", func(r *basicRequest) { r.Name = "al" }, "name", "must be at least 3 characters"},
+		{"too long", func(r *basicRequest) { r.Name = "alice1" }, "name", "must be at most 5 characters"},
+		{"length counts characters", func(r *basicRequest) { r.Name = "张三李四五" }, "", ""},
+		{"email", func(r *basicRequest) { r.Email = ptr("alice@example.com") }, "", ""},
+		{"invalid email", func(r *basicRequest) { r.Email = ptr("alice@") }, "email", "must be a valid email address"},
+		{"empty email with omitempty", func(r *basicRequest) { r.Email = ptr("") }, "", ""},
+		{"eth address", func(r *basicRequest) { r.Address = "0x52908400098527886E0F7030069857D2E4169EE7" }, "", ""},
+		{"invalid eth address", func(r *basicRequest) { r.Address = "0x1234" }, "address", "must be a valid ethereum address"},
+		{"https url", func(r *basicRequest) { r.Website = ptr("https://example.com/a") }, "", ""},
+		{"javascript url", func(r *basicRequest) { r.Website = ptr("javascript:alert(1)") }, "website", "must be an http or https URL"},
+		{"url without host", func(r *basicRequest) { r.Website = ptr("http://") }, "website", "must be an http or https URL"},
+		{"oneof", func(r *basicRequest) { r.Level = "info" }, "", ""},
+		{"not oneof", func(r *basicRequest) { r.Level = "trace" }, "level", "must be one of debug, info"},
+		{"slice max", func(r *basicRequest) { r.Tags = []string{"a", "b", "c"} }, "tags", "must be at most 2 items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(&req)
+			got := details(t, &req)
+			if tt.field == "" {
+				if got != nil {
+					t.Fatalf("unexpected errors %+v", got)
+				}
+				return
+			}
+			want := []apperrors.FieldError{{Field: tt.field, Message: tt.msg}}
+			if !slices.Equal(got, want) {
+				t.Errorf("errors = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestValidateEnums(t *testing.T) {
+	tests := []struct {
+		name string
+		req  enumRequest
+		want []apperrors.FieldError
+	}{
+		{"valid", enumRequest{Role: ptr(models.UserRoleDeveloper), AuthType: models.AuthTypeWeb3}, nil},
+		{"every role", enumRequest{Role: ptr(models.UserRoleAdmin), AuthType: models.AuthTypeGitHub}, nil},
+		{"missing auth type", enumRequest{}, []apperrors.FieldError{{Field: "auth_type", Message: "is required"}}},
+		{"unknown auth type", enumRequest{AuthType: "password"}, []apperrors.FieldError{{Field: "auth_type", Message: `invalid value "password"`}}},
+		{"unknown role", enumRequest{Role: ptr(models.UserRole("owner")), AuthType: models.AuthTypeGoogle}, []apperrors.FieldError{{Field: "role", Message: `invalid value "owner"`}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := details(t, tt.req); !slices.Equal(got, tt.want) {
+				t.Errorf("errors = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNestedAndPointerFields(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *nestedRequest
+		want []apperrors.FieldError
+	}{
+		{"valid", &nestedRequest{Owner: ptr(valid()), Links: []models.ProfileLink{{Label: "Blog", URL: "https://blog.example"}}}, nil},
+		{"nil pointer is missing", &nestedRequest{}, []apperrors.FieldError{{Field: "owner", Message: "is required"}}},
+		{"nested struct", &nestedRequest{Owner: &basicRequest{Name: "al"}}, []apperrors.FieldError{{Field: "owner.name", Message: "must be at least 3 characters"}}},
+		{
+			"slice elements",
+			&nestedRequest{Owner: ptr(valid()), Links: []models.ProfileLink{{Label: "ok", URL: "https://ok.example"}, {URL: "ftp://files"}}},
+			[]apperrors.FieldError{
+				{Field: "links[1].label", Message: "is required"},
+				{Field: "links[1].url", Message: "must be an http or https URL"},
+			},
+		},
+		{
+			"pointer slice elements",
+			&nestedRequest{Owner: ptr(valid()), Extra: []*models.ProfileLink{nil, {Label: strings.Repeat("x", 51), URL: "https://ok.example"}}},
+			[]apperrors.FieldError{{Field: "extra[1].label", Message: "must be at most 50 characters"}},
+		},
+		{
+			"slice max before elements",
+			&nestedRequest{Owner: ptr(valid()), Links: make([]models.ProfileLink, 4)},
+			[]apperrors.FieldError{{Field: "links", Message: "must be at most 3 items"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := details(t, tt.req); !slices.Equal(got, tt.want) {
+				t.Errorf("errors = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAggregatesDetails(t *testing.T) {
+	req := &models.UpdateUserRequest{
+		Username:  ptr("al"),
+		Email:     ptr("not-an-email"),
+		AvatarURL: ptr("data:image/png;base64,AAAA"),
+		Links:     []models.ProfileLink{{Label: "x"}},
+	}
+	want := []apperrors.FieldError{
+		{Field: "username", Message: "must be at least 3 characters"},
+		{Field: "email", Message: "must be a valid email address"},
+		{Field: "avatar_url", Message: "must be an http or https URL"},
+		{Field: "links[0].url", Message: "is required"},
+	}
+	if got := details(t, req); !slices.Equal(got, want) {
+		t.Errorf("errors = %+v, want %+v", got, want)
+	}
+
+	// 未提交的可选字段不校验，空字符串可用于清除
+	if got := details(t, &models.UpdateUserRequest{AvatarURL: ptr("")}); got != nil {
+		t.Errorf("clearing avatar_url: %+v", got)
+	}
+}
+
+func TestValidateIgnoresNonStructs(t *testing.T) {
+	var nilReq *basicRequest
+	for _, in := range []any{nil, nilReq, "text", 42} {
+		if err := New().Validate(in); err != nil {
+			t.Errorf("Validate(%#v) = %v", in, err)
+		}
+	}
+}
@@ -1,34 +0,0 @@
-package main
-
-import (
-	"fmt"
-	"log"
-
-	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
-	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/middleware"
-	"github.com/gofiber/fiber/v3"
-)
-
-func main() {
-	// Initialize a new Fiber app
-	app := fiber.New()
-
-	app.Use(
-		// Middleware
-		middleware.AuthMiddleware(
-			config.Load(),
-		),
-	)
-
-	// Define a route for the GET method on the root path '/'
-	app.Get("/", func(c fiber.Ctx) error {
-		// Send a string response to the client
-		return c.SendString("Hello, World 👋!")
-	})
-
-	config := config.Load()
-	port := config.ServerPort
-
-	// Start the server on port 3000
-	log.Fatal(app.Listen(fmt.Sprintf(":%s", port)))
-}
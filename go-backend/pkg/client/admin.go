@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// LogLevel 服务端当前日志级别 GET /admin/log-level
+func (c *Client) LogLevel(ctx context.Context) (string, error) {
+	var out models.LogLevelResponse
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/admin/log-level", nil, nil, &out); err != nil {
+		return "", err
+	}
+	return out.Level, nil
+}
+
+// SetLogLevel 运行时修改服务端日志级别 PUT /admin/log-level
+func (c *Client) SetLogLevel(ctx context.Context, level string) (string, error) {
+	var out models.LogLevelResponse
+	req := models.LogLevelRequest{Level: level}
+	if _, err := c.call(ctx, http.MethodPut, apiPrefix+"/admin/log-level", nil, req, &out); err != nil {
+		return "", err
+	}
+	return out.Level, nil
+}
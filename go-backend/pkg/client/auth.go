@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/services"
+)
+
+// authCookieName 服务端存放JWT的cookie，与 middleware.AuthCookieName 一致
+const authCookieName = "auth_token"
+
+// Signer 用钱包私钥对挑战签名
+type Signer interface {
+	// Address 钱包地址
+	Address() string
+	// SignMessage 返回 personal_sign 格式的签名
+	SignMessage(message string) (string, error)
+}
+
+// privateKeySigner 持有私钥的签名器，签名方式与服务端校验使用的 Web3Service 一致
+type privateKeySigner struct {
+	privateKey string
+	address    string
+	web3       *services.Web3Service
+}
+
+// NewPrivateKeySigner 根据十六进制私钥创建签名器，适用于CI等无浏览器钱包的场景
+func NewPrivateKeySigner(privateKeyHex string) (Signer, error) {
+	web3 := services.NewWeb3Service()
+	if !web3.IsValidPrivateKey(privateKeyHex) {
+		return nil, fmt.Errorf("invalid private key")
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return &privateKeySigner{
+		privateKey: privateKeyHex,
+		address:    crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		web3:       web3,
+	}, nil
+}
+
+func (s *privateKeySigner) Address() string {
+	return s.address
+}
+
+func (s *privateKeySigner) SignMessage(message string) (string, error) {
+	return s.web3.SignMessage(message, s.privateKey)
+}
+
+// Web3Challenge 获取钱包登录挑战 GET /user/auth/web3/challenge
+func (c *Client) Web3Challenge(ctx context.Context, address string) (*Web3ChallengeResponse, error) {
+	var out Web3ChallengeResponse
+	query := url.Values{"address": {address}}
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/user/auth/web3/challenge", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyWeb3Auth 提交签名完成登录或注册 POST /user/auth/web3/verify，
+// 成功后客户端使用服务端签发的JWT
+func (c *Client) VerifyWeb3Auth(ctx context.Context, req *Web3AuthRequest) (*Web3AuthResponse, error) {
+	path := apiPrefix + "/user/auth/web3/verify"
+	resp, raw, err := c.send(ctx, http.MethodPost, path, nil, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Web3AuthResponse
+	if _, err := decode(raw, &out); err != nil {
+		return nil, fmt.Errorf("decode POST %s response: %w", path, err)
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == authCookieName && cookie.Value != "" {
+			c.SetToken(cookie.Value)
+		}
+	}
+	return &out, nil
+}
+
+// Login 获取挑战、签名并登录。req 可以为nil，新用户注册时可在其中指定用户名和邮箱，
+// 恢复删除宽限期内的账户时设置 Restore
+func (c *Client) Login(ctx context.Context, signer Signer, req *Web3AuthRequest) (*Web3AuthResponse, error) {
+	challenge, err := c.Web3Challenge(ctx, signer.Address())
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.SignMessage(challenge.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("sign challenge: %w", err)
+	}
+
+	auth := Web3AuthRequest{}
+	if req != nil {
+		auth = *req
+	}
+	auth.Address = signer.Address()
+	auth.Nonce = challenge.Nonce
+	auth.Signature = signature
+	return c.VerifyWeb3Auth(ctx, &auth)
+}
+
+// Logout 登出 POST /user/auth/logout，并清除客户端保存的JWT
+func (c *Client) Logout(ctx context.Context) error {
+	_, err := c.call(ctx, http.MethodPost, apiPrefix+"/user/auth/logout", nil, nil, nil)
+	c.SetToken("")
+	return err
+}
@@ -0,0 +1,203 @@
+// Package client MCPForge API 的Go客户端。
+//
+// 请求和响应类型与服务端 internal/models 中的DTO为同一类型，接口变更时编译期即可发现。
+// 目前Go后端提供用户、钱包认证、收款地址、开发者申请、管理接口以及MCP服务器和卡片的只读接口；
+// 部署服务器、发布卡片、API密钥和计费接口尚未迁移，客户端也暂不提供
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// apiPrefix 接口路径前缀
+const apiPrefix = "/api/v1"
+
+// defaultUserAgent 未指定时发送的User-Agent
+const defaultUserAgent = "mcpforge-go-client"
+
+// Client MCPForge API 客户端，可并发使用
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	retry      RetryPolicy
+	userAgent  string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Option 客户端选项
+type Option func(*Client)
+
+// WithHTTPClient 使用自定义的HTTP客户端，例如设置超时或代理
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithToken 使用已有的JWT，跳过登录
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithRetryPolicy 设置重试策略，NoRetry 关闭重试
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) { c.retry = policy }
+}
+
+// WithUserAgent 设置请求的User-Agent
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// New 创建客户端，baseURL 为服务地址，如 https://api.mcpforge.dev
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+		userAgent:  defaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Token 返回当前使用的JWT，未登录时为空
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// SetToken 设置后续请求使用的JWT
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// envelope 服务端统一的响应格式
+type envelope struct {
+	Success    bool            `json:"success"`
+	Data       json.RawMessage `json:"data"`
+	Pagination *Pagination     `json:"pagination"`
+}
+
+// call 发送请求并将响应的 data 解码到out，返回响应中的分页信息
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, body, out any) (*Pagination, error) {
+	_, raw, err := c.send(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination, err := decode(raw, out)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s %s response: %w", method, path, err)
+	}
+	return pagination, nil
+}
+
+// decode 解析统一格式的响应，out 为nil时忽略 data
+func decode(raw []byte, out any) (*Pagination, error) {
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, err
+	}
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return nil, err
+		}
+	}
+	return env.Pagination, nil
+}
+
+// send 按重试策略发送请求，返回2xx响应和响应体。非2xx响应转换为 *APIError
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body any) (*http.Response, []byte, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, nil, fmt.Errorf("encode %s %s request: %w", method, path, err)
+		}
+	}
+
+	target := c.baseURL.JoinPath(path)
+	target.RawQuery = query.Encode()
+
+	for attempt := 1; ; attempt++ {
+		resp, raw, err := c.attempt(ctx, method, target.String(), payload)
+		if err == nil {
+			return resp, raw, nil
+		}
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+
+		wait, retry := c.retry.next(method, attempt, resp, err)
+		if !retry {
+			return nil, nil, err
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// attempt 发送一次请求，非2xx响应返回 *APIError 以及原始响应用于判断是否重试
+func (c *Client) attempt(ctx context.Context, method, target string, payload []byte) (*http.Response, []byte, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := c.Token(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, nil, newAPIError(resp, raw)
+	}
+	return resp, raw, nil
+}
+
+// isAPIError 判断错误是否来自服务端的错误响应
+func isAPIError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr)
+}
@@ -0,0 +1,489 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apitest"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/config"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/pkg/client"
+)
+
+// testServer 基于真实路由和服务、内存仓储的API服务
+type testServer struct {
+	*apitest.Server
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	return &testServer{Server: apitest.New(t)}
+}
+
+func (s *testServer) client(t *testing.T) *client.Client {
+	t.Helper()
+	c, err := client.New(s.URL, client.WithRetryPolicy(client.NoRetry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// newSigner 使用新生成的私钥创建签名器
+func newSigner(t *testing.T) client.Signer {
+	t.Helper()
+	signer, err := client.NewPrivateKeySigner(apitest.NewWallet(t).PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// login 用新钱包注册并登录
+func login(t *testing.T, c *client.Client, username string) (*client.Web3AuthResponse, client.Signer) {
+	t.Helper()
+	signer := newSigner(t)
+	resp, err := c.Login(context.Background(), signer, &client.Web3AuthRequest{Username: &username})
+	if err != nil {
+		t.Fatalf("login %s: %v", username, err)
+	}
+	return resp, signer
+}
+
+func TestLoginAndManageAccount(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t)
+	c := srv.client(t)
+
+	auth, signer := login(t, c, "alice")
+	if auth.Action != "register" || auth.User.Username != "alice" {
+		t.Fatalf("unexpected auth response %+v", auth)
+	}
+	if c.Token() == "" {
+		t.Fatal("token not stored after login")
+	}
+	id := auth.User.UserID
+
+	user, err := c.GetUser(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(user.AuthMethods) != 1 || user.AuthMethods[0].AuthType != client.AuthTypeWeb3 {
+		t.Errorf("auth methods = %+v", user.AuthMethods)
+	}
+
+	displayName := "Alice"
+	updated, err := c.UpdateUser(ctx, id, &client.UpdateUserRequest{DisplayName: &displayName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.DisplayName == nil || *updated.DisplayName != displayName {
+		t.Errorf("display name = %v", updated.DisplayName)
+	}
+
+	profile, err := c.GetPublicProfile(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.DisplayName == nil || *profile.DisplayName != displayName {
+		t.Errorf("profile display name = %v", profile.DisplayName)
+	}
+
+	export, err := c.ExportMyData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if export.Profile.UserID != id || len(export.AuthMethods) != 1 {
+		t.Errorf("unexpected export %+v", export)
+	}
+
+	deleted, err := c.DeleteUser(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted.RestoreUntil <= time.Now().Unix() {
+		t.Errorf("restore_until = %d", deleted.RestoreUntil)
+	}
+	if _, err := c.GetUser(ctx, id); client.ErrorCode(err) != "user_gone" {
+		t.Errorf("GetUser after delete: %v", err)
+	}
+
+	// 宽限期内重新登录需要明确恢复
+	if _, err := c.Login(ctx, signer, nil); client.ErrorCode(err) != "account_pending_deletion" {
+		t.Errorf("login after delete: %v", err)
+	}
+	restored, err := c.Login(ctx, signer, &client.Web3AuthRequest{Restore: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Action != "restore" || restored.User.UserID != id {
+		t.Errorf("unexpected restore response %+v", restored)
+	}
+
+	if err := c.Logout(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetUser(ctx, id); client.StatusCode(err) != http.StatusUnauthorized {
+		t.Errorf("GetUser after logout: %v", err)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t)
+	alice := srv.client(t)
+	bob := srv.client(t)
+	aliceAuth, _ := login(t, alice, "alice")
+	login(t, bob, "bobby")
+
+	_, err := bob.GetUser(ctx, aliceAuth.User.UserID)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusForbidden || apiErr.Code != "not_account_owner" || apiErr.RequestID == "" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+
+	short := "ab"
+	_, err = alice.UpdateUser(ctx, aliceAuth.User.UserID, &client.UpdateUserRequest{Username: &short})
+	if !errors.As(err, &apiErr) || apiErr.Code != "validation_failed" || len(apiErr.Details) == 0 || apiErr.Details[0].Field != "username" {
+		t.Errorf("expected username validation error, got %v", err)
+	}
+}
+
+func TestAllUsersFollowsCursor(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t)
+	c := srv.client(t)
+
+	names := []string{"user-a", "user-b", "user-c", "user-d", "user-e"}
+	for _, name := range names {
+		login(t, c, name)
+	}
+	if _, err := c.ListUsers(ctx, client.ListUsersParams{}); client.StatusCode(err) != http.StatusForbidden {
+		t.Errorf("non-admin listed users: %v", err)
+	}
+
+	// 用户列表只对管理员开放
+	admin, _ := srv.LoginAs(t, "admin", models.UserRoleAdmin)
+	c.SetToken(admin.Token)
+	names = append(names, "admin")
+
+	page, err := c.ListUsers(ctx, client.ListUsersParams{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Users) != 2 || page.Pagination.Total != int64(len(names)) || page.Pagination.NextCursor == "" {
+		t.Fatalf("unexpected first page %+v", page.Pagination)
+	}
+
+	// 游标绑定签发时的排序方式
+	_, err = c.ListUsers(ctx, client.ListUsersParams{Limit: 2, Cursor: page.Pagination.NextCursor, Sort: client.UserSortByUsername})
+	if client.ErrorCode(err) != "validation_failed" {
+		t.Errorf("cursor reused with another sort: expected validation_failed, got %v", err)
+	}
+
+	var got []string
+	for user, err := range c.AllUsers(ctx, client.ListUsersParams{Limit: 2, Sort: client.UserSortByUsername, Order: client.SortDesc}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, user.Username)
+	}
+	want := []string{"user-e", "user-d", "user-c", "user-b", "user-a", "admin"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	// 提前结束遍历
+	count := 0
+	for range c.AllUsers(ctx, client.ListUsersParams{Limit: 2}) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	if count != 3 {
+		t.Errorf("iterated %d users after break", count)
+	}
+
+	for _, err := range c.AllUsers(ctx, client.ListUsersParams{Cursor: "not-a-cursor"}) {
+		if client.ErrorCode(err) != "invalid_cursor" {
+			t.Errorf("expected invalid_cursor, got %v", err)
+		}
+	}
+}
+
+func TestDeveloperApplicationReview(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t)
+	applicant := srv.client(t)
+	auth, _ := login(t, applicant, "applicant")
+
+	submitted, err := applicant.SubmitDeveloperApplication(ctx, &client.RoleApplicationRequest{
+		GithubHandle:    "applicant",
+		IntendedServers: []string{"weather"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if submitted.Status != client.RoleApplicationPending {
+		t.Errorf("status = %s", submitted.Status)
+	}
+	if _, err := applicant.ListRoleApplications(ctx, ""); client.StatusCode(err) != http.StatusForbidden {
+		t.Errorf("non-admin listed applications: %v", err)
+	}
+
+	adminSigner := newSigner(t)
+	srv.Provision(t, "admin", adminSigner.Address(), models.UserRoleAdmin)
+	admin := srv.client(t)
+	if _, err := admin.Login(ctx, adminSigner, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := admin.ListRoleApplications(ctx, client.RoleApplicationPending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ID != submitted.ID {
+		t.Fatalf("pending applications = %+v", pending)
+	}
+
+	notes := "welcome"
+	if _, err := admin.ApproveRoleApplication(ctx, submitted.ID, &notes); err != nil {
+		t.Fatal(err)
+	}
+	reviewed, err := admin.GetRoleApplication(ctx, submitted.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reviewed.Status != client.RoleApplicationApproved || len(reviewed.Events) != 2 {
+		t.Errorf("unexpected reviewed application %+v", reviewed)
+	}
+
+	user, err := applicant.GetUser(ctx, auth.User.UserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Role != client.UserRoleDeveloper {
+		t.Errorf("role after approval = %s", user.Role)
+	}
+
+	// 申请中填写的GitHub用户名公开展示，但标记为未验证
+	profile, err := applicant.GetPublicProfile(ctx, "applicant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.GithubHandle == nil || *profile.GithubHandle != "applicant" || profile.GithubHandleVerified {
+		t.Errorf("profile github handle = %v, verified %v", profile.GithubHandle, profile.GithubHandleVerified)
+	}
+}
+
+func TestAdminLogLevel(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t)
+
+	user := srv.client(t)
+	login(t, user, "alice")
+	if _, err := user.SetLogLevel(ctx, "debug"); client.StatusCode(err) != http.StatusForbidden {
+		t.Errorf("non-admin changed the log level: %v", err)
+	}
+
+	adminSigner := newSigner(t)
+	srv.Provision(t, "admin", adminSigner.Address(), models.UserRoleAdmin)
+	admin := srv.client(t)
+	if _, err := admin.Login(ctx, adminSigner, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	level, err := admin.SetLogLevel(ctx, "debug")
+	if err != nil || level != "debug" {
+		t.Errorf("SetLogLevel = %q, %v", level, err)
+	}
+	if level, err := admin.LogLevel(ctx); err != nil || level != "debug" {
+		t.Errorf("LogLevel = %q, %v", level, err)
+	}
+}
+
+func TestAdminLogLevelProduction(t *testing.T) {
+	ctx := context.Background()
+	srv := &testServer{Server: apitest.New(t, func(cfg *config.Config) { cfg.Server.Env = config.EnvProduction })}
+
+	adminSigner := newSigner(t)
+	srv.Provision(t, "admin", adminSigner.Address(), models.UserRoleAdmin)
+	admin := srv.client(t)
+	if _, err := admin.Login(ctx, adminSigner, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := admin.SetLogLevel(ctx, "debug")
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Details) != 1 || apiErr.Details[0].Field != "level" {
+		t.Fatalf("SetLogLevel(debug) in production = %v", err)
+	}
+	if level, err := admin.LogLevel(ctx); err != nil || level == "debug" {
+		t.Errorf("LogLevel = %q, %v", level, err)
+	}
+
+	if level, err := admin.SetLogLevel(ctx, "warn"); err != nil || level != "warn" {
+		t.Errorf("SetLogLevel(warn) = %q, %v", level, err)
+	}
+}
+
+func TestMCPReads(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t)
+	srv.Store.AddMCPServer(models.MCPServer{Name: "old", Image: "example/old:1"})
+	srv.Store.AddMCPServer(models.MCPServer{Name: "weather", Image: "example/weather:1"})
+	name := "Weather"
+	card := srv.Store.AddMCPCard(models.MCPCard{Name: &name, GithubURL: "https://github.com/example/weather"})
+
+	// 读取接口无需登录
+	c := srv.client(t)
+	servers, err := c.ListMCPServers(ctx)
+	if err != nil || len(servers) != 2 || servers[0].Name != "weather" {
+		t.Fatalf("ListMCPServers = %+v, %v", servers, err)
+	}
+	server, err := c.GetMCPServer(ctx, "weather")
+	if err != nil || server.Image != "example/weather:1" {
+		t.Errorf("GetMCPServer = %+v, %v", server, err)
+	}
+	if _, err := c.GetMCPServer(ctx, "missing"); client.StatusCode(err) != http.StatusNotFound {
+		t.Errorf("missing server: %v", err)
+	}
+
+	cards, err := c.ListMCPCards(ctx)
+	if err != nil || len(cards) != 1 || cards[0].ID != card.ID {
+		t.Fatalf("ListMCPCards = %+v, %v", cards, err)
+	}
+	got, err := c.GetMCPCard(ctx, card.ID)
+	if err != nil || got.GithubURL != card.GithubURL {
+		t.Errorf("GetMCPCard = %+v, %v", got, err)
+	}
+	if _, err := c.GetMCPCard(ctx, card.ID+1); client.StatusCode(err) != http.StatusNotFound {
+		t.Errorf("missing card: %v", err)
+	}
+}
+
+func TestRewardAddressChange(t *testing.T) {
+	ctx := context.Background()
+	srv := newTestServer(t)
+	c := srv.client(t)
+	login(t, c, "earner")
+
+	wallet := newSigner(t)
+	change, err := c.ChangeRewardAddress(ctx, wallet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change.Status != client.RewardAddressPending {
+		t.Errorf("status = %s", change.Status)
+	}
+
+	overview, err := c.GetRewardAddress(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overview.Pending == nil || overview.Pending.ID != change.ID {
+		t.Errorf("pending = %+v", overview.Pending)
+	}
+
+	cancelled, err := c.CancelRewardAddressChange(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Status != client.RewardAddressCancelled {
+		t.Errorf("status after cancel = %s", cancelled.Status)
+	}
+}
+
+// flakyServer 前failures次请求返回status，之后返回成功响应
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(`{"success":false,"code":"service_unavailable","message":"try again"}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"status":"healthy"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+	policy := client.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	srv, calls := flakyServer(t, 2, http.StatusServiceUnavailable)
+	c, _ := client.New(srv.URL, client.WithRetryPolicy(policy))
+	status, err := c.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Status != "healthy" || calls.Load() != 3 {
+		t.Errorf("status %q after %d calls", status.Status, calls.Load())
+	}
+
+	// POST 在服务端可能已处理时不重试
+	srv, calls = flakyServer(t, 1, http.StatusServiceUnavailable)
+	c, _ = client.New(srv.URL, client.WithRetryPolicy(policy))
+	if err := c.Logout(ctx); client.StatusCode(err) != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("POST retried: %v after %d calls", err, calls.Load())
+	}
+
+	// 429 表示请求未被处理，POST 也会重试
+	srv, calls = flakyServer(t, 1, http.StatusTooManyRequests)
+	c, _ = client.New(srv.URL, client.WithRetryPolicy(policy))
+	if err := c.Logout(ctx); err != nil || calls.Load() != 2 {
+		t.Errorf("POST after 429: %v after %d calls", err, calls.Load())
+	}
+
+	// 重试次数用尽后返回最后一次的错误
+	srv, calls = flakyServer(t, 10, http.StatusServiceUnavailable)
+	c, _ = client.New(srv.URL, client.WithRetryPolicy(policy))
+	if _, err := c.Status(ctx); client.ErrorCode(err) != "service_unavailable" || calls.Load() != 3 {
+		t.Errorf("exhausted retries: %v after %d calls", err, calls.Load())
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(blocked.Close)
+
+	c, _ := client.New(blocked.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Status(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	// 等待重试期间取消
+	srv, calls := flakyServer(t, 10, http.StatusServiceUnavailable)
+	c, _ = client.New(srv.URL, client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 5, MinBackoff: time.Hour, MaxBackoff: time.Hour}))
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.Status(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second || calls.Load() != 1 {
+		t.Errorf("cancellation during backoff took %s after %d calls", time.Since(start), calls.Load())
+	}
+}
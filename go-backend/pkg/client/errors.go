@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError 服务端返回的错误响应
+type APIError struct {
+	StatusCode int
+	// Code 错误码，如 user_not_found、validation_failed
+	Code    string
+	Message string
+	Details []FieldError
+	// RequestID 响应头中的 X-Request-ID，便于对照服务端日志
+	RequestID string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("mcpforge: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	for _, d := range e.Details {
+		msg += fmt.Sprintf("; %s: %s", d.Field, d.Message)
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request id %s)", e.RequestID)
+	}
+	return msg
+}
+
+// newAPIError 解析错误响应，响应体不是统一的错误格式时使用状态码描述
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+
+	var payload struct {
+		Code    string       `json:"code"`
+		Message string       `json:"message"`
+		Details []FieldError `json:"details"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Code != "" {
+		apiErr.Code = payload.Code
+		apiErr.Message = payload.Message
+		apiErr.Details = payload.Details
+		return apiErr
+	}
+
+	apiErr.Code = "http_error"
+	apiErr.Message = http.StatusText(resp.StatusCode)
+	return apiErr
+}
+
+// ErrorCode 返回服务端错误的错误码，err 不是 *APIError 时返回空字符串
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// StatusCode 返回服务端错误的HTTP状态码，err 不是 *APIError 时返回0
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
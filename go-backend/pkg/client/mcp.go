@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListMCPServers 列出MCP服务器，无需登录 GET /mcp/servers
+func (c *Client) ListMCPServers(ctx context.Context) ([]MCPServer, error) {
+	var out []MCPServer
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/mcp/servers", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMCPServer 根据名称获取MCP服务器 GET /mcp/servers/:name
+func (c *Client) GetMCPServer(ctx context.Context, name string) (*MCPServer, error) {
+	var out MCPServer
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/mcp/servers/"+url.PathEscape(name), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListMCPCards 列出MCP卡片，无需登录 GET /mcp/cards
+func (c *Client) ListMCPCards(ctx context.Context) ([]MCPCard, error) {
+	var out []MCPCard
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/mcp/cards", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMCPCard 根据ID获取MCP卡片 GET /mcp/cards/:id
+func (c *Client) GetMCPCard(ctx context.Context, id uint) (*MCPCard, error) {
+	var out MCPCard
+	path := apiPrefix + "/mcp/cards/" + strconv.FormatUint(uint64(id), 10)
+	if _, err := c.call(ctx, http.MethodGet, path, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
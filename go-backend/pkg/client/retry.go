@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy 请求失败时的重试策略，等待时间按指数退避并加入随机抖动。
+//
+// 只有服务端未处理请求的情况才会重试所有方法（429）；网络错误和 502/503/504
+// 只重试幂等的 GET、PUT、DELETE，避免重复提交登录、申请等POST请求
+type RetryPolicy struct {
+	// MaxAttempts 包含首次请求在内的最大尝试次数，小于等于1时不重试
+	MaxAttempts int
+	// MinBackoff 第一次重试前的等待时间
+	MinBackoff time.Duration
+	// MaxBackoff 单次等待时间的上限，同样限制服务端 Retry-After 的值
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy 默认重试策略
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  200 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+}
+
+// NoRetry 不重试
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// next 判断第attempt次请求失败后是否重试，以及重试前的等待时间
+func (p RetryPolicy) next(method string, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || !retryable(method, resp, err) {
+		return 0, false
+	}
+
+	wait := p.backoff(attempt)
+	if resp != nil {
+		if after, ok := retryAfter(resp); ok {
+			wait = after
+		}
+	}
+	if p.MaxBackoff > 0 {
+		wait = min(wait, p.MaxBackoff)
+	}
+	return wait, true
+}
+
+// backoff 第attempt次失败后的等待时间，在 [d/2, d) 之间随机
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff << (attempt - 1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half)
+}
+
+// retryable 判断失败的请求能否安全重试
+func retryable(method string, resp *http.Response, err error) bool {
+	idempotent := method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+
+	if !isAPIError(err) {
+		// 连接失败或读取响应失败，无法确认服务端是否已处理
+		return idempotent
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// retryAfter 解析秒数形式的 Retry-After 响应头
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// sleep 等待d，ctx取消时提前返回
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
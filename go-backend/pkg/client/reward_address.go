@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// GetRewardAddress 当前收款地址、待生效变更和历史 GET /user/me/reward-address
+func (c *Client) GetRewardAddress(ctx context.Context) (*RewardAddressOverview, error) {
+	var out RewardAddressOverview
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/user/me/reward-address", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RewardAddressChallenge 获取新收款地址的所有权挑战 POST /user/me/reward-address/challenge
+func (c *Client) RewardAddressChallenge(ctx context.Context, address string) (*Web3ChallengeResponse, error) {
+	var out Web3ChallengeResponse
+	req := models.RewardAddressChallengeRequest{Address: address}
+	if _, err := c.call(ctx, http.MethodPost, apiPrefix+"/user/me/reward-address/challenge", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RequestRewardAddressChange 提交签名后的收款地址变更，冷静期后生效 POST /user/me/reward-address
+func (c *Client) RequestRewardAddressChange(ctx context.Context, req *RewardAddressChangeRequest) (*RewardAddressChange, error) {
+	var out RewardAddressChange
+	if _, err := c.call(ctx, http.MethodPost, apiPrefix+"/user/me/reward-address", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ChangeRewardAddress 用新地址的签名器完成挑战并提交变更
+func (c *Client) ChangeRewardAddress(ctx context.Context, signer Signer) (*RewardAddressChange, error) {
+	challenge, err := c.RewardAddressChallenge(ctx, signer.Address())
+	if err != nil {
+		return nil, err
+	}
+	signature, err := signer.SignMessage(challenge.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("sign challenge: %w", err)
+	}
+
+	return c.RequestRewardAddressChange(ctx, &RewardAddressChangeRequest{
+		Address:   signer.Address(),
+		Signature: signature,
+		Nonce:     challenge.Nonce,
+	})
+}
+
+// CancelRewardAddressChange 取消冷静期内的变更 DELETE /user/me/reward-address/pending
+func (c *Client) CancelRewardAddressChange(ctx context.Context) (*RewardAddressChange, error) {
+	var out RewardAddressChange
+	if _, err := c.call(ctx, http.MethodDelete, apiPrefix+"/user/me/reward-address/pending", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// SubmitDeveloperApplication 申请开发者角色 POST /user/me/developer-application
+func (c *Client) SubmitDeveloperApplication(ctx context.Context, req *RoleApplicationRequest) (*RoleApplication, error) {
+	var out RoleApplication
+	if _, err := c.call(ctx, http.MethodPost, apiPrefix+"/user/me/developer-application", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MyDeveloperApplications 自己的申请历史 GET /user/me/developer-application
+func (c *Client) MyDeveloperApplications(ctx context.Context) ([]RoleApplication, error) {
+	var out []RoleApplication
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/user/me/developer-application", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListRoleApplications 管理员按状态列出申请，status 为空时列出全部 GET /admin/role-applications
+func (c *Client) ListRoleApplications(ctx context.Context, status RoleApplicationStatus) ([]RoleApplication, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+
+	var out []RoleApplication
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/admin/role-applications", query, nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetRoleApplication 管理员查看申请及审计事件 GET /admin/role-applications/:id
+func (c *Client) GetRoleApplication(ctx context.Context, id uint) (*RoleApplication, error) {
+	var out RoleApplication
+	if _, err := c.call(ctx, http.MethodGet, roleApplicationPath(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ApproveRoleApplication 通过申请 POST /admin/role-applications/:id/approve
+func (c *Client) ApproveRoleApplication(ctx context.Context, id uint, notes *string) (*RoleApplication, error) {
+	return c.reviewRoleApplication(ctx, id, "approve", notes)
+}
+
+// RejectRoleApplication 拒绝申请 POST /admin/role-applications/:id/reject
+func (c *Client) RejectRoleApplication(ctx context.Context, id uint, notes *string) (*RoleApplication, error) {
+	return c.reviewRoleApplication(ctx, id, "reject", notes)
+}
+
+func (c *Client) reviewRoleApplication(ctx context.Context, id uint, action string, notes *string) (*RoleApplication, error) {
+	var out RoleApplication
+	req := models.RoleApplicationReviewRequest{Notes: notes}
+	if _, err := c.call(ctx, http.MethodPost, roleApplicationPath(id)+"/"+action, nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func roleApplicationPath(id uint) string {
+	return apiPrefix + "/admin/role-applications/" + strconv.FormatUint(uint64(id), 10)
+}
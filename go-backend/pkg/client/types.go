@@ -0,0 +1,72 @@
+package client
+
+import (
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/apperrors"
+	"github.com/YoubetDao/MCPForge-Backend/go-backend/internal/models"
+)
+
+// 请求和响应类型直接使用服务端的DTO，其他模块通过这里的别名引用
+
+type (
+	User               = models.User
+	UserRole           = models.UserRole
+	AuthMethod         = models.AuthMethod
+	AuthType           = models.AuthType
+	ProfileLink        = models.ProfileLink
+	UpdateUserRequest  = models.UpdateUserRequest
+	DeleteUserResponse = models.DeleteUserResponse
+	UserDataExport     = models.UserDataExport
+	UserSortField      = models.UserSortField
+	SortOrder          = models.SortOrder
+	Pagination         = models.Pagination
+
+	PublicProfile   = models.PublicProfile
+	PublicMCPServer = models.PublicMCPServer
+	PublicMCPCard   = models.PublicMCPCard
+	ServiceStatus   = models.ServiceStatus
+
+	MCPServer = models.MCPServer
+	MCPCard   = models.MCPCard
+
+	Web3ChallengeResponse = models.Web3ChallengeResponse
+	Web3AuthRequest       = models.Web3AuthRequest
+	Web3AuthResponse      = models.Web3AuthResponse
+
+	RewardAddressOverview      = models.RewardAddressOverview
+	RewardAddressChange        = models.RewardAddressChange
+	RewardAddressStatus        = models.RewardAddressStatus
+	RewardAddressChangeRequest = models.RewardAddressChangeRequest
+
+	RoleApplication        = models.RoleApplication
+	RoleApplicationEvent   = models.RoleApplicationEvent
+	RoleApplicationStatus  = models.RoleApplicationStatus
+	RoleApplicationRequest = models.RoleApplicationRequest
+
+	FieldError = apperrors.FieldError
+)
+
+const (
+	UserRoleUser      = models.UserRoleUser
+	UserRoleDeveloper = models.UserRoleDeveloper
+	UserRoleAdmin     = models.UserRoleAdmin
+
+	AuthTypeWeb3   = models.AuthTypeWeb3
+	AuthTypeGoogle = models.AuthTypeGoogle
+	AuthTypeGitHub = models.AuthTypeGitHub
+
+	UserSortByID        = models.UserSortByID
+	UserSortByUsername  = models.UserSortByUsername
+	UserSortByCreatedAt = models.UserSortByCreatedAt
+	UserSortByUpdatedAt = models.UserSortByUpdatedAt
+	SortAsc             = models.SortAsc
+	SortDesc            = models.SortDesc
+
+	RoleApplicationPending  = models.RoleApplicationPending
+	RoleApplicationApproved = models.RoleApplicationApproved
+	RoleApplicationRejected = models.RoleApplicationRejected
+
+	RewardAddressPending    = models.RewardAddressPending
+	RewardAddressActive     = models.RewardAddressActive
+	RewardAddressSuperseded = models.RewardAddressSuperseded
+	RewardAddressCancelled  = models.RewardAddressCancelled
+)
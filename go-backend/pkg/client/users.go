@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ListUsersParams 用户列表的查询条件，零值字段不发送
+type ListUsersParams struct {
+	Role           UserRole
+	AuthType       AuthType
+	AuthIdentifier string // 需要同时指定 AuthType
+	UsernamePrefix string
+	EmailPrefix    string
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+
+	Sort  UserSortField
+	Order SortOrder
+
+	// Cursor 不为空时使用游标分页并忽略Offset
+	Cursor string
+	Limit  int
+	Offset int
+}
+
+func (p ListUsersParams) values() url.Values {
+	v := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+	set("role", string(p.Role))
+	set("auth_type", string(p.AuthType))
+	set("auth_identifier", p.AuthIdentifier)
+	set("username_prefix", p.UsernamePrefix)
+	set("email_prefix", p.EmailPrefix)
+	if !p.CreatedAfter.IsZero() {
+		set("created_after", p.CreatedAfter.Format(time.RFC3339))
+	}
+	if !p.CreatedBefore.IsZero() {
+		set("created_before", p.CreatedBefore.Format(time.RFC3339))
+	}
+	set("sort", string(p.Sort))
+	set("order", string(p.Order))
+	set("cursor", p.Cursor)
+	if p.Limit > 0 {
+		set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Offset > 0 && p.Cursor == "" {
+		set("offset", strconv.Itoa(p.Offset))
+	}
+	return v
+}
+
+// UserPage 一页用户
+type UserPage struct {
+	Users      []User
+	Pagination Pagination
+}
+
+// Status 服务状态和构建信息 GET /status
+func (c *Client) Status(ctx context.Context) (*ServiceStatus, error) {
+	var out ServiceStatus
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/status", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListUsers 查询一页用户 GET /user
+func (c *Client) ListUsers(ctx context.Context, params ListUsersParams) (*UserPage, error) {
+	var users []User
+	pagination, err := c.call(ctx, http.MethodGet, apiPrefix+"/user", params.values(), nil, &users)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &UserPage{Users: users}
+	if pagination != nil {
+		page.Pagination = *pagination
+	}
+	return page, nil
+}
+
+// AllUsers 按游标逐页遍历符合条件的全部用户，params.Offset 被忽略。
+// 请求失败时产出错误并结束遍历
+func (c *Client) AllUsers(ctx context.Context, params ListUsersParams) iter.Seq2[User, error] {
+	return func(yield func(User, error) bool) {
+		params.Offset = 0
+		for {
+			page, err := c.ListUsers(ctx, params)
+			if err != nil {
+				yield(User{}, err)
+				return
+			}
+			for _, user := range page.Users {
+				if !yield(user, nil) {
+					return
+				}
+			}
+			if page.Pagination.NextCursor == "" {
+				return
+			}
+			params.Cursor = page.Pagination.NextCursor
+		}
+	}
+}
+
+// GetUser 获取用户，只能查看自己的账户，管理员除外 GET /user/:id
+func (c *Client) GetUser(ctx context.Context, id uint) (*User, error) {
+	var out User
+	if _, err := c.call(ctx, http.MethodGet, userPath(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateUser 更新用户资料 PUT /user/:id
+func (c *Client) UpdateUser(ctx context.Context, id uint, req *UpdateUserRequest) (*User, error) {
+	var out User
+	if _, err := c.call(ctx, http.MethodPut, userPath(id), nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteUser 删除账户，宽限期内可通过再次登录恢复 DELETE /user/:id
+func (c *Client) DeleteUser(ctx context.Context, id uint) (*DeleteUserResponse, error) {
+	var out DeleteUserResponse
+	if _, err := c.call(ctx, http.MethodDelete, userPath(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportMyData 导出当前用户的个人数据 GET /user/me/export
+func (c *Client) ExportMyData(ctx context.Context) (*UserDataExport, error) {
+	path := apiPrefix + "/user/me/export"
+	// 导出接口直接返回数据，不使用统一的响应格式
+	_, raw, err := c.send(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out UserDataExport
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("decode GET %s response: %w", path, err)
+	}
+	return &out, nil
+}
+
+// GetPublicProfile 获取公开资料，无需登录 GET /profiles/:username
+func (c *Client) GetPublicProfile(ctx context.Context, username string) (*PublicProfile, error) {
+	var out PublicProfile
+	if _, err := c.call(ctx, http.MethodGet, apiPrefix+"/profiles/"+url.PathEscape(username), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func userPath(id uint) string {
+	return apiPrefix + "/user/" + strconv.FormatUint(uint64(id), 10)
+}
@@ -2,35 +2,31 @@ package logger
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/gofiber/fiber/v3"
-	"github.com/gofiber/fiber/v3/middleware/logger"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Logger struct {
 	*slog.Logger
+	// level 由同一个 New 派生出的所有记录器共享，可在运行时调整
+	level *slog.LevelVar
 }
 
 func New(level string) *Logger {
-	var slogLevel slog.Level
-	switch level {
-	case "debug":
-		slogLevel = slog.LevelDebug
-	case "info":
-		slogLevel = slog.LevelInfo
-	case "warn":
-		slogLevel = slog.LevelWarn
-	case "error":
-		slogLevel = slog.LevelError
-	default:
+	slogLevel, err := ParseLevel(level)
+	if err != nil {
 		slogLevel = slog.LevelInfo
 	}
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(slogLevel)
 
 	opts := &slog.HandlerOptions{
-		Level: slogLevel,
+		Level: levelVar,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			if a.Key == slog.TimeKey {
 				return slog.Attr{Key: slog.TimeKey, Value: slog.TimeValue(time.Now())}
@@ -39,19 +35,50 @@ func New(level string) *Logger {
 		},
 	}
 
-	handler := slog.NewJSONHandler(os.Stdout, opts)
+	handler := traceHandler{Handler: slog.NewJSONHandler(os.Stdout, opts)}
 	logger := slog.New(handler)
-	
+
 	return &Logger{
 		Logger: logger,
+		level:  levelVar,
+	}
+}
+
+// ParseLevel 解析 debug、info、warn、error 日志级别
+func ParseLevel(level string) (slog.Level, error) {
+	switch level {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+}
+
+// Level 当前日志级别
+func (l *Logger) Level() string {
+	if l.level == nil {
+		return ""
 	}
+	return strings.ToLower(l.level.Level().String())
 }
 
-func (l *Logger) Middleware() fiber.Handler {
-	return logger.New(logger.Config{
-		Format:     "${time} | ${status} | ${latency} | ${method} | ${path} | ${error}\n",
-		TimeFormat: "2006-01-02 15:04:05",
-	})
+// SetLevel 在运行时调整日志级别，对同一个 New 派生出的所有记录器生效
+func (l *Logger) SetLevel(level string) error {
+	if l.level == nil {
+		return fmt.Errorf("log level is not adjustable")
+	}
+	slogLevel, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.Set(slogLevel)
+	return nil
 }
 
 func (l *Logger) Info(msg string, args ...any) {
@@ -70,14 +97,34 @@ func (l *Logger) Warn(msg string, args ...any) {
 	l.Logger.Warn(msg, args...)
 }
 
-func (l *Logger) With(key string, value any) *Logger {
+func (l *Logger) With(args ...any) *Logger {
 	return &Logger{
-		Logger: l.Logger.With(key, value),
+		Logger: l.Logger.With(args...),
+		level:  l.level,
 	}
 }
 
+// WithContext 优先返回ctx中的请求日志记录器，否则返回附带ctx中trace_id和span_id的l
 func (l *Logger) WithContext(ctx context.Context) *Logger {
-	return &Logger{
-		Logger: slog.New(l.Logger.Handler()),
+	if reqLogger, ok := ctx.Value(contextKey{}).(*Logger); ok {
+		return reqLogger
 	}
-}
\ No newline at end of file
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return l
+	}
+	return l.With(traceAttrs(spanCtx)...)
+}
+
+// contextKey 请求日志记录器在context中的键
+type contextKey struct{}
+
+// NewContext 返回携带请求日志记录器的context
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext 返回ctx中的请求日志记录器，没有时基于 slog.Default 创建
+func FromContext(ctx context.Context) *Logger {
+	return (&Logger{Logger: slog.Default()}).WithContext(ctx)
+}
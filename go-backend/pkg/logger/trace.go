@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHandler 为带有span的上下文中记录的日志附加trace_id和span_id，
+// 使用 InfoContext 等方法或 WithContext 时生效
+type traceHandler struct {
+	slog.Handler
+	// hasTrace 记录器已通过 With 附带trace字段时不再重复添加
+	hasTrace bool
+}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() && !h.hasTrace {
+		r.AddAttrs(slog.String("trace_id", spanCtx.TraceID().String()), slog.String("span_id", spanCtx.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	hasTrace := h.hasTrace
+	for _, a := range attrs {
+		if a.Key == "trace_id" {
+			hasTrace = true
+		}
+	}
+	return traceHandler{Handler: h.Handler.WithAttrs(attrs), hasTrace: hasTrace}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{Handler: h.Handler.WithGroup(name), hasTrace: h.hasTrace}
+}
+
+// traceAttrs span上下文对应的日志字段
+func traceAttrs(spanCtx trace.SpanContext) []any {
+	return []any{"trace_id", spanCtx.TraceID().String(), "span_id", spanCtx.SpanID().String()}
+}
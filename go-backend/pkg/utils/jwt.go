@@ -17,21 +17,36 @@ type JWTClaims struct {
 
 type JWTUtil struct {
 	secretKey []byte
+	// previousKeys 轮换后仍接受验证的旧密钥
+	previousKeys [][]byte
+	expiresIn    time.Duration
 }
 
 func NewJWTUtil(cfg *config.Config) *JWTUtil {
+	previousKeys := make([][]byte, 0, len(cfg.Auth.JWTPreviousSecrets))
+	for _, secret := range cfg.Auth.JWTPreviousSecrets {
+		previousKeys = append(previousKeys, []byte(secret))
+	}
+
 	return &JWTUtil{
-		secretKey: []byte(cfg.JWTSecret),
+		secretKey:    []byte(cfg.Auth.JWTSecret),
+		previousKeys: previousKeys,
+		expiresIn:    time.Duration(cfg.Auth.JWTExpiresHours) * time.Hour,
 	}
 }
 
 func (j *JWTUtil) GenerateToken(userID uint, username, role string) (string, error) {
+	return j.GenerateTokenWithTTL(userID, username, role, j.expiresIn)
+}
+
+// GenerateTokenWithTTL 签发指定有效期的token
+func (j *JWTUtil) GenerateTokenWithTTL(userID uint, username, role string, ttl time.Duration) (string, error) {
 	claims := JWTClaims{
 		UserID:   userID,
 		Username: username,
 		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "MCPForge",
 		},
@@ -41,10 +56,22 @@ func (j *JWTUtil) GenerateToken(userID uint, username, role string) (string, err
 	return token.SignedString(j.secretKey)
 }
 
+// VerifyToken 先用当前密钥验证，签名不匹配时再依次尝试旧密钥
 func (j *JWTUtil) VerifyToken(tokenString string) (*JWTClaims, error) {
+	claims, err := j.verifyWithKey(tokenString, j.secretKey)
+	for _, key := range j.previousKeys {
+		if err == nil || !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			break
+		}
+		claims, err = j.verifyWithKey(tokenString, key)
+	}
+	return claims, err
+}
+
+func (j *JWTUtil) verifyWithKey(tokenString string, key []byte) (*JWTClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return j.secretKey, nil
-	})
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err
@@ -38,14 +38,6 @@ func FormatTime(t time.Time) string {
 	return t.Format("2006-01-02 15:04:05")
 }
 
-func ErrorResponse(c fiber.Ctx, status int, message string) error {
-	return c.Status(status).JSON(fiber.Map{
-		"success": false,
-		"message": message,
-		"timestamp": time.Now().Unix(),
-	})
-}
-
 func SuccessResponse(c fiber.Ctx, data interface{}) error {
 	return c.JSON(fiber.Map{
 		"success": true,
@@ -54,6 +46,15 @@ func SuccessResponse(c fiber.Ctx, data interface{}) error {
 	})
 }
 
+func PaginatedResponse(c fiber.Ctx, data interface{}, pagination interface{}) error {
+	return c.JSON(fiber.Map{
+		"success":    true,
+		"data":       data,
+		"pagination": pagination,
+		"timestamp":  time.Now().Unix(),
+	})
+}
+
 func Contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {